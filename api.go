@@ -8,6 +8,7 @@ package confluence
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -221,9 +222,17 @@ type Content struct {
 	Ancestors   []*Content   `json:"ancestors"`
 	Descendants *Contents    `json:"descendants"`
 	Body        *Body        `json:"body"`
+	History     *History     `json:"history"`
 	Links       *Links       `json:"_links"`
 }
 
+// Comment contains comment info
+type Comment struct {
+	Content
+
+	Parent *Content `json:"parent"` // Commented page or blog post
+}
+
 // ContentCollection represents paginated list of content
 type ContentCollection struct {
 	Results []*Content `json:"results"`
@@ -290,8 +299,9 @@ type Operation struct {
 
 // Metadata contains metadata records
 type Metadata struct {
-	Labels    *LabelCollection `json:"labels"`    // Page
-	MediaType string           `json:"mediaType"` // Attachment
+	Labels     *LabelCollection            `json:"labels"`     // Page
+	Properties map[string]*ContentProperty `json:"properties"` // Page
+	MediaType  string                      `json:"mediaType"`  // Attachment
 }
 
 // History contains info about content history
@@ -325,6 +335,63 @@ type Container struct {
 	Links *Links      `json:"_links"`
 }
 
+// ContentInput contains data for creating or updating content
+type ContentInput struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    string              `json:"status,omitempty"`
+	Space     *SpaceReference     `json:"space,omitempty"`
+	Ancestors []*ContentReference `json:"ancestors,omitempty"`
+	Body      *BodyInput          `json:"body,omitempty"`
+	Version   *VersionInput       `json:"version,omitempty"`
+}
+
+// ContentReference is reference to content
+type ContentReference struct {
+	ID string `json:"id"`
+}
+
+// SpaceReference is reference to space
+type SpaceReference struct {
+	Key string `json:"key"`
+}
+
+// BodyInput contains content body in storage format
+type BodyInput struct {
+	Storage *View `json:"storage"`
+}
+
+// VersionInput contains info about new version
+type VersionInput struct {
+	Number      int    `json:"number"`
+	Message     string `json:"message,omitempty"`
+	IsMinorEdit bool   `json:"minorEdit,omitempty"`
+}
+
+// ContentProperty contains content property
+type ContentProperty struct {
+	ID      string          `json:"id,omitempty"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version *VersionInput   `json:"version,omitempty"`
+}
+
+// ContentPropertyCollection contains paginated list of content properties
+type ContentPropertyCollection struct {
+	Results []*ContentProperty `json:"results"`
+	Start   int                `json:"start"`
+	Limit   int                `json:"limit"`
+	Size    int                `json:"size"`
+}
+
+// AttachmentInput contains data for uploading attachment
+type AttachmentInput struct {
+	FileName    string
+	Data        []byte
+	Comment     string
+	IsMinorEdit bool
+}
+
 // LABELS //////////////////////////////////////////////////////////////////////////////
 
 // LabelParameters is params for fetching labels
@@ -346,7 +413,7 @@ type LabelCollection struct {
 type Label struct {
 	Prefix string `json:"prefix"`
 	Name   string `json:"name"`
-	ID     string `json:"id"`
+	ID     string `json:"id,omitempty"`
 }
 
 // GROUPS //////////////////////////////////////////////////////////////////////////////
@@ -438,12 +505,13 @@ type SpaceParameters struct {
 
 // Space contains info about space
 type Space struct {
-	ID    int    `json:"id"`
-	Key   string `json:"key"`
-	Name  string `json:"name"`
-	Icon  *Icon  `json:"icon"`
-	Type  string `json:"type"`
-	Links *Links `json:"_links"`
+	ID       int      `json:"id"`
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	Icon     *Icon    `json:"icon"`
+	Type     string   `json:"type"`
+	Homepage *Content `json:"homepage"`
+	Links    *Links   `json:"_links"`
 }
 
 // SpaceCollection contains paginated list of spaces
@@ -466,11 +534,12 @@ type Icon struct {
 
 // UserParameters is params for fetching info about user
 type UserParameters struct {
-	Key      string   `query:"key"`
-	Username string   `query:"username"`
-	Expand   []string `query:"expand"`
-	Start    int      `query:"start"`
-	Limit    int      `query:"limit"`
+	Key       string   `query:"key"`
+	Username  string   `query:"username"`
+	AccountID string   `query:"accountId"` // Cloud
+	Expand    []string `query:"expand"`
+	Start     int      `query:"start"`
+	Limit     int      `query:"limit"`
 }
 
 // User contains user info
@@ -478,10 +547,19 @@ type User struct {
 	Type           string `json:"type"`
 	Name           string `json:"username"`
 	Key            string `json:"userKey"`
+	AccountID      string `json:"accountId"`   // Cloud
+	AccountType    string `json:"accountType"` // Cloud
+	Email          string `json:"email"`       // Cloud
 	ProfilePicture *Icon  `json:"profilePicture"`
 	DisplayName    string `json:"displayName"`
 }
 
+// UserDetails contains user details for update
+type UserDetails struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Email       string `json:"email,omitempty"`
+}
+
 // UserCollection contains paginated list of users
 type UserCollection struct {
 	Results []*User `json:"results"`
@@ -494,9 +572,12 @@ type UserCollection struct {
 
 // Links contains links
 type Links struct {
-	WebUI  string `json:"webui"`
-	TinyUI string `json:"tinyui"`
-	Base   string `json:"base"`
+	Self     string `json:"self"`
+	WebUI    string `json:"webui"`
+	EditUI   string `json:"editui"`
+	TinyUI   string `json:"tinyui"`
+	Download string `json:"download"`
+	Base     string `json:"base"`
 }
 
 // WATCH ///////////////////////////////////////////////////////////////////////////////
@@ -505,6 +586,7 @@ type Links struct {
 type WatchParameters struct {
 	Key         string `query:"key"`
 	Username    string `query:"username"`
+	AccountID   string `query:"accountId"` // Cloud
 	ContentType string `query:"contentType"`
 }
 
@@ -529,10 +611,73 @@ type Watcher struct {
 	AvatarURL   string `json:"avatarUrl"`
 	Name        string `json:"name"`
 	Key         string `json:"userKey"`
+	AccountID   string `json:"accountId"` // Cloud
 	DisplayName string `json:"fullName"`
 	Type        string `json:"type"`
 }
 
+// WEBHOOKS ////////////////////////////////////////////////////////////////////////////
+
+// WebhookParameters is params for fetching webhooks
+type WebhookParameters struct {
+	Event      string `query:"event"`
+	Statistics bool   `query:"statistics"`
+	Start      int    `query:"start"`
+	Limit      int    `query:"limit"`
+}
+
+// WebhookTestParameters is params for testing webhook endpoint
+type WebhookTestParameters struct {
+	URL string `query:"url"`
+}
+
+// Webhook contains webhook info
+type Webhook struct {
+	ID            int                   `json:"id,omitempty"`
+	Name          string                `json:"name"`
+	URL           string                `json:"url"`
+	Events        []WebhookEvent        `json:"events"`
+	IsActive      bool                  `json:"active"`
+	Configuration *WebhookConfiguration `json:"configuration,omitempty"`
+	CreatedDate   *Timestamp            `json:"createdDate,omitempty"`
+	UpdatedDate   *Timestamp            `json:"updatedDate,omitempty"`
+}
+
+// WebhookConfiguration contains webhook configuration
+type WebhookConfiguration struct {
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookCollection contains paginated list of webhooks
+type WebhookCollection struct {
+	Results []*Webhook `json:"results"`
+	Start   int        `json:"start"`
+	Limit   int        `json:"limit"`
+	Size    int        `json:"size"`
+}
+
+// WebhookTestResult contains result of webhook endpoint test
+type WebhookTestResult struct {
+	Request  *WebhookTestRequest  `json:"request"`
+	Response *WebhookTestResponse `json:"response"`
+	Error    string               `json:"error"`
+}
+
+// WebhookTestRequest contains info about test request
+type WebhookTestRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// WebhookTestResponse contains info about response to test request
+type WebhookTestResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // IsAttachment return true if content is attachment
@@ -594,7 +739,7 @@ func (wi *WatchInfo) Combined() []*Watcher {
 MAINLOOP:
 	for _, watcher := range wi.SpaceWatchers {
 		for _, pageWatcher := range wi.PageWatchers {
-			if watcher.Key == pageWatcher.Key {
+			if watcher.ID() == pageWatcher.ID() {
 				continue MAINLOOP
 			}
 		}
@@ -605,6 +750,24 @@ MAINLOOP:
 	return result
 }
 
+// ID returns unique user identifier (account ID for Cloud and user key for Server)
+func (u *User) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+
+	return u.Key
+}
+
+// ID returns unique watcher identifier (account ID for Cloud and user key for Server)
+func (w *Watcher) ID() string {
+	if w.AccountID != "" {
+		return w.AccountID
+	}
+
+	return w.Key
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // UnmarshalJSON is custom Date format unmarshaler
@@ -750,8 +913,8 @@ func (p SpaceParameters) Validate() error {
 
 // Validate validates parameters
 func (p UserParameters) Validate() error {
-	if p.Key == "" && p.Username == "" {
-		return errors.New("Key or Username must be set")
+	if p.Key == "" && p.Username == "" && p.AccountID == "" {
+		return errors.New("Key, Username or AccountID must be set")
 	}
 
 	return nil
@@ -767,6 +930,20 @@ func (p ListWatchersParameters) Validate() error {
 	return nil
 }
 
+// Validate validates parameters
+func (p WebhookParameters) Validate() error {
+	return nil
+}
+
+// Validate validates parameters
+func (p WebhookTestParameters) Validate() error {
+	if p.URL == "" {
+		return errors.New("URL is mandatory and must be set")
+	}
+
+	return nil
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // ToQuery convert params to URL query
@@ -848,3 +1025,13 @@ func (p WatchParameters) ToQuery() string {
 func (p ListWatchersParameters) ToQuery() string {
 	return paramsToQuery(p)
 }
+
+// ToQuery convert params to URL query
+func (p WebhookParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p WebhookTestParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
@@ -0,0 +1,848 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"iter"
+	"net/url"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _V2_BASE is base path of Confluence Cloud REST API v2
+const _V2_BASE = "/api/v2"
+
+// Body formats
+const (
+	BODY_FORMAT_STORAGE          = "storage"
+	BODY_FORMAT_ATLAS_DOC_FORMAT = "atlas_doc_format"
+	BODY_FORMAT_VIEW             = "view"
+)
+
+// Sort orders
+const (
+	SORT_ID                 = "id"
+	SORT_ID_DESC            = "-id"
+	SORT_CREATED_DATE       = "created-date"
+	SORT_CREATED_DATE_DESC  = "-created-date"
+	SORT_MODIFIED_DATE      = "modified-date"
+	SORT_MODIFIED_DATE_DESC = "-modified-date"
+	SORT_TITLE              = "title"
+	SORT_TITLE_DESC         = "-title"
+)
+
+// Inline comment resolution status
+const (
+	RESOLUTION_STATUS_OPEN     = "open"
+	RESOLUTION_STATUS_REOPENED = "reopened"
+	RESOLUTION_STATUS_RESOLVED = "resolved"
+	RESOLUTION_STATUS_DANGLING = "dangling"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// APIv2 is Confluence Cloud REST API v2 client. It shares transport, authorization
+// and middlewares with the parent API struct.
+type APIv2 struct {
+	api *API
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PageListParameters is params for fetching list of pages
+type PageListParameters struct {
+	ID         []string `query:"id"`
+	SpaceID    []string `query:"space-id"`
+	Status     []string `query:"status"`
+	Title      string   `query:"title"`
+	Sort       string   `query:"sort"`
+	BodyFormat string   `query:"body-format"`
+	Cursor     string   `query:"cursor"`
+	Limit      int      `query:"limit"`
+}
+
+// PageParameters is params for fetching page
+type PageParameters struct {
+	BodyFormat string `query:"body-format"`
+	Version    int    `query:"version"`
+	GetDraft   bool   `query:"get-draft"`
+}
+
+// BlogPostListParameters is params for fetching list of blog posts
+type BlogPostListParameters struct {
+	ID         []string `query:"id"`
+	SpaceID    []string `query:"space-id"`
+	Status     []string `query:"status"`
+	Title      string   `query:"title"`
+	Sort       string   `query:"sort"`
+	BodyFormat string   `query:"body-format"`
+	Cursor     string   `query:"cursor"`
+	Limit      int      `query:"limit"`
+}
+
+// BlogPostParameters is params for fetching blog post
+type BlogPostParameters struct {
+	BodyFormat string `query:"body-format"`
+	Version    int    `query:"version"`
+	GetDraft   bool   `query:"get-draft"`
+}
+
+// CommentListParameters is params for fetching list of comments
+type CommentListParameters struct {
+	Status     []string `query:"status"`
+	Sort       string   `query:"sort"`
+	BodyFormat string   `query:"body-format"`
+	Cursor     string   `query:"cursor"`
+	Limit      int      `query:"limit"`
+}
+
+// CommentParameters is params for fetching comment
+type CommentParameters struct {
+	BodyFormat string `query:"body-format"`
+	Version    int    `query:"version"`
+}
+
+// SpaceListParameters is params for fetching list of spaces
+type SpaceListParameters struct {
+	ID                []string `query:"ids"`
+	Key               []string `query:"keys"`
+	Type              string   `query:"type"`
+	Status            string   `query:"status"`
+	Labels            []string `query:"labels"`
+	Sort              string   `query:"sort"`
+	DescriptionFormat string   `query:"description-format"`
+	Cursor            string   `query:"cursor"`
+	Limit             int      `query:"limit"`
+}
+
+// DescriptionParameters is params for fetching entity with description
+type DescriptionParameters struct {
+	DescriptionFormat string `query:"description-format"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CursorCollection contains list of results with cursor-based pagination
+type CursorCollection[T any] struct {
+	Results []T          `json:"results"`
+	Links   *CursorLinks `json:"_links"`
+}
+
+// CursorLinks contains pagination links
+type CursorLinks struct {
+	Next string `json:"next"`
+	Base string `json:"base"`
+}
+
+// Page contains page info
+type Page struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Title      string     `json:"title"`
+	SpaceID    string     `json:"spaceId"`
+	ParentID   string     `json:"parentId"`
+	ParentType string     `json:"parentType"`
+	Position   *int       `json:"position"`
+	AuthorID   string     `json:"authorId"`
+	OwnerID    string     `json:"ownerId"`
+	CreatedAt  *Date      `json:"createdAt"`
+	Version    *VersionV2 `json:"version"`
+	Body       *BodyV2    `json:"body"`
+	Links      *Links     `json:"_links"`
+}
+
+// BlogPost contains blog post info
+type BlogPost struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	Title     string     `json:"title"`
+	SpaceID   string     `json:"spaceId"`
+	AuthorID  string     `json:"authorId"`
+	CreatedAt *Date      `json:"createdAt"`
+	Version   *VersionV2 `json:"version"`
+	Body      *BodyV2    `json:"body"`
+	Links     *Links     `json:"_links"`
+}
+
+// FooterComment contains footer comment info
+type FooterComment struct {
+	ID              string     `json:"id"`
+	Status          string     `json:"status"`
+	Title           string     `json:"title"`
+	PageID          string     `json:"pageId"`
+	BlogPostID      string     `json:"blogPostId"`
+	AttachmentID    string     `json:"attachmentId"`
+	ParentCommentID string     `json:"parentCommentId"`
+	Version         *VersionV2 `json:"version"`
+	Body            *BodyV2    `json:"body"`
+	Links           *Links     `json:"_links"`
+}
+
+// InlineComment contains inline comment info
+type InlineComment struct {
+	ID               string                   `json:"id"`
+	Status           string                   `json:"status"`
+	Title            string                   `json:"title"`
+	PageID           string                   `json:"pageId"`
+	BlogPostID       string                   `json:"blogPostId"`
+	ParentCommentID  string                   `json:"parentCommentId"`
+	ResolutionStatus string                   `json:"resolutionStatus"`
+	Properties       *InlineCommentProperties `json:"properties"`
+	Version          *VersionV2               `json:"version"`
+	Body             *BodyV2                  `json:"body"`
+	Links            *Links                   `json:"_links"`
+}
+
+// InlineCommentProperties contains info about commented text
+type InlineCommentProperties struct {
+	MarkerRef         string `json:"inlineMarkerRef"`
+	OriginalSelection string `json:"inlineOriginalSelection"`
+}
+
+// SpaceV2 contains space info
+type SpaceV2 struct {
+	ID          string         `json:"id"`
+	Key         string         `json:"key"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Status      string         `json:"status"`
+	AuthorID    string         `json:"authorId"`
+	HomepageID  string         `json:"homepageId"`
+	CreatedAt   *Date          `json:"createdAt"`
+	Description *DescriptionV2 `json:"description"`
+	Icon        *Icon          `json:"icon"`
+	Links       *Links         `json:"_links"`
+}
+
+// DescriptionV2 contains space description
+type DescriptionV2 struct {
+	Plain *View `json:"plain"`
+	View  *View `json:"view"`
+}
+
+// VersionV2 contains info about content version
+type VersionV2 struct {
+	CreatedAt   *Date  `json:"createdAt"`
+	Message     string `json:"message"`
+	Number      int    `json:"number"`
+	AuthorID    string `json:"authorId"`
+	IsMinorEdit bool   `json:"minorEdit"`
+}
+
+// BodyV2 contains content body in different formats
+type BodyV2 struct {
+	Storage        *View `json:"storage"`
+	AtlasDocFormat *View `json:"atlas_doc_format"`
+	View           *View `json:"view"`
+}
+
+// PageInput contains page data for creating or updating page
+type PageInput struct {
+	ID       string          `json:"id,omitempty"`
+	Status   string          `json:"status,omitempty"`
+	Title    string          `json:"title"`
+	SpaceID  string          `json:"spaceId,omitempty"`
+	ParentID string          `json:"parentId,omitempty"`
+	Body     *BodyInputV2    `json:"body,omitempty"`
+	Version  *VersionInputV2 `json:"version,omitempty"`
+}
+
+// BlogPostInput contains blog post data for creating or updating blog post
+type BlogPostInput struct {
+	ID      string          `json:"id,omitempty"`
+	Status  string          `json:"status,omitempty"`
+	Title   string          `json:"title"`
+	SpaceID string          `json:"spaceId,omitempty"`
+	Body    *BodyInputV2    `json:"body,omitempty"`
+	Version *VersionInputV2 `json:"version,omitempty"`
+}
+
+// FooterCommentInput contains footer comment data for creating or updating
+// comment. Only one of PageID, BlogPostID and ParentCommentID must be set.
+type FooterCommentInput struct {
+	PageID          string          `json:"pageId,omitempty"`
+	BlogPostID      string          `json:"blogPostId,omitempty"`
+	ParentCommentID string          `json:"parentCommentId,omitempty"`
+	Body            *BodyInputV2    `json:"body"`
+	Version         *VersionInputV2 `json:"version,omitempty"`
+}
+
+// InlineCommentInput contains inline comment data for creating or updating
+// comment. Only one of PageID, BlogPostID and ParentCommentID must be set.
+type InlineCommentInput struct {
+	PageID          string                  `json:"pageId,omitempty"`
+	BlogPostID      string                  `json:"blogPostId,omitempty"`
+	ParentCommentID string                  `json:"parentCommentId,omitempty"`
+	Body            *BodyInputV2            `json:"body"`
+	Version         *VersionInputV2         `json:"version,omitempty"`
+	Selection       *InlineCommentSelection `json:"inlineCommentProperties,omitempty"`
+	IsResolved      *bool                   `json:"resolved,omitempty"`
+}
+
+// InlineCommentSelection contains info about text commented by new inline comment
+type InlineCommentSelection struct {
+	Text       string `json:"textSelection"`
+	MatchCount int    `json:"textSelectionMatchCount"`
+	MatchIndex int    `json:"textSelectionMatchIndex"`
+}
+
+// BodyInputV2 contains content body in given format
+type BodyInputV2 struct {
+	Representation string `json:"representation"`
+	Value          string `json:"value"`
+}
+
+// VersionInputV2 contains info about new content version
+type VersionInputV2 struct {
+	Number  int    `json:"number"`
+	Message string `json:"message,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrNoComment is returned if comment doesn't exist
+var ErrNoComment = errors.New("There is no comment with the given id, or if the calling user does not have permission to view the comment")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// V2 returns client for Confluence Cloud REST API v2
+func (api *API) V2() *APIv2 {
+	return &APIv2{api}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetPages fetch list of pages
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-get
+func (api *APIv2) GetPages(params PageListParameters) (*CursorCollection[*Page], error) {
+	return getCursorCollection[*Page](api, "GetPages", _V2_BASE+"/pages", params, ErrNoContent)
+}
+
+// GetSpacePages fetch list of pages in given space
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-spaces-id-pages-get
+func (api *APIv2) GetSpacePages(spaceID string, params PageListParameters) (*CursorCollection[*Page], error) {
+	return getCursorCollection[*Page](api, "GetSpacePages", _V2_BASE+"/spaces/"+spaceID+"/pages", params, ErrNoSpace)
+}
+
+// GetPageChildren fetch list of direct children of given page
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-children/#api-pages-id-children-get
+func (api *APIv2) GetPageChildren(pageID string, params PageListParameters) (*CursorCollection[*Page], error) {
+	return getCursorCollection[*Page](api, "GetPageChildren", _V2_BASE+"/pages/"+pageID+"/children", params, ErrNoContent)
+}
+
+// GetPage fetch page with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-id-get
+func (api *APIv2) GetPage(pageID string, params PageParameters) (*Page, error) {
+	return getEntity[Page](api, "GetPage", _V2_BASE+"/pages/"+pageID, params, ErrNoContent)
+}
+
+// GetBlogPosts fetch list of blog posts
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-get
+func (api *APIv2) GetBlogPosts(params BlogPostListParameters) (*CursorCollection[*BlogPost], error) {
+	return getCursorCollection[*BlogPost](api, "GetBlogPosts", _V2_BASE+"/blogposts", params, ErrNoContent)
+}
+
+// GetSpaceBlogPosts fetch list of blog posts in given space
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-spaces-id-blogposts-get
+func (api *APIv2) GetSpaceBlogPosts(spaceID string, params BlogPostListParameters) (*CursorCollection[*BlogPost], error) {
+	return getCursorCollection[*BlogPost](api, "GetSpaceBlogPosts", _V2_BASE+"/spaces/"+spaceID+"/blogposts", params, ErrNoSpace)
+}
+
+// GetBlogPost fetch blog post with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-id-get
+func (api *APIv2) GetBlogPost(blogPostID string, params BlogPostParameters) (*BlogPost, error) {
+	return getEntity[BlogPost](api, "GetBlogPost", _V2_BASE+"/blogposts/"+blogPostID, params, ErrNoContent)
+}
+
+// GetPageFooterComments fetch list of footer comments of given page
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-pages-id-footer-comments-get
+func (api *APIv2) GetPageFooterComments(pageID string, params CommentListParameters) (*CursorCollection[*FooterComment], error) {
+	return getCursorCollection[*FooterComment](api, "GetPageFooterComments", _V2_BASE+"/pages/"+pageID+"/footer-comments", params, ErrNoContent)
+}
+
+// GetPageInlineComments fetch list of inline comments of given page
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-pages-id-inline-comments-get
+func (api *APIv2) GetPageInlineComments(pageID string, params CommentListParameters) (*CursorCollection[*InlineComment], error) {
+	return getCursorCollection[*InlineComment](api, "GetPageInlineComments", _V2_BASE+"/pages/"+pageID+"/inline-comments", params, ErrNoContent)
+}
+
+// GetBlogPostFooterComments fetch list of footer comments of given blog post
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-blogposts-id-footer-comments-get
+func (api *APIv2) GetBlogPostFooterComments(blogPostID string, params CommentListParameters) (*CursorCollection[*FooterComment], error) {
+	return getCursorCollection[*FooterComment](api, "GetBlogPostFooterComments", _V2_BASE+"/blogposts/"+blogPostID+"/footer-comments", params, ErrNoContent)
+}
+
+// GetBlogPostInlineComments fetch list of inline comments of given blog post
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-blogposts-id-inline-comments-get
+func (api *APIv2) GetBlogPostInlineComments(blogPostID string, params CommentListParameters) (*CursorCollection[*InlineComment], error) {
+	return getCursorCollection[*InlineComment](api, "GetBlogPostInlineComments", _V2_BASE+"/blogposts/"+blogPostID+"/inline-comments", params, ErrNoContent)
+}
+
+// GetFooterComment fetch footer comment with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-comment-id-get
+func (api *APIv2) GetFooterComment(commentID string, params CommentParameters) (*FooterComment, error) {
+	return getEntity[FooterComment](api, "GetFooterComment", _V2_BASE+"/footer-comments/"+commentID, params, ErrNoComment)
+}
+
+// GetInlineComment fetch inline comment with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-inline-comments-comment-id-get
+func (api *APIv2) GetInlineComment(commentID string, params CommentParameters) (*InlineComment, error) {
+	return getEntity[InlineComment](api, "GetInlineComment", _V2_BASE+"/inline-comments/"+commentID, params, ErrNoComment)
+}
+
+// GetSpaces fetch list of spaces
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-space/#api-spaces-get
+func (api *APIv2) GetSpaces(params SpaceListParameters) (*CursorCollection[*SpaceV2], error) {
+	return getCursorCollection[*SpaceV2](api, "GetSpaces", _V2_BASE+"/spaces", params, ErrNoSpace)
+}
+
+// GetSpace fetch space with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-space/#api-spaces-id-get
+func (api *APIv2) GetSpace(spaceID string, params DescriptionParameters) (*SpaceV2, error) {
+	return getEntity[SpaceV2](api, "GetSpace", _V2_BASE+"/spaces/"+spaceID, params, ErrNoSpace)
+}
+
+// GetFooterComments fetch list of all footer comments
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-get
+func (api *APIv2) GetFooterComments(params CommentListParameters) (*CursorCollection[*FooterComment], error) {
+	return getCursorCollection[*FooterComment](api, "GetFooterComments", _V2_BASE+"/footer-comments", params, ErrNoComment)
+}
+
+// GetInlineComments fetch list of all inline comments
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-inline-comments-get
+func (api *APIv2) GetInlineComments(params CommentListParameters) (*CursorCollection[*InlineComment], error) {
+	return getCursorCollection[*InlineComment](api, "GetInlineComments", _V2_BASE+"/inline-comments", params, ErrNoComment)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CreatePage creates new page
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-post
+func (api *APIv2) CreatePage(page *PageInput) (*Page, error) {
+	return sendEntity[Page](api, "CreatePage", "POST", _V2_BASE+"/pages", page, ErrNoSpace)
+}
+
+// UpdatePage updates page with given ID. Version number must be incremented.
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-id-put
+func (api *APIv2) UpdatePage(pageID string, page *PageInput) (*Page, error) {
+	return sendEntity[Page](api, "UpdatePage", "PUT", _V2_BASE+"/pages/"+pageID, page, ErrNoContent)
+}
+
+// DeletePage moves page with given ID to the trash
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-id-delete
+func (api *APIv2) DeletePage(pageID string) error {
+	return deleteEntity(api, "DeletePage", _V2_BASE+"/pages/"+pageID, ErrNoContent)
+}
+
+// CreateBlogPost creates new blog post
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-post
+func (api *APIv2) CreateBlogPost(blogPost *BlogPostInput) (*BlogPost, error) {
+	return sendEntity[BlogPost](api, "CreateBlogPost", "POST", _V2_BASE+"/blogposts", blogPost, ErrNoSpace)
+}
+
+// UpdateBlogPost updates blog post with given ID. Version number must be
+// incremented.
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-id-put
+func (api *APIv2) UpdateBlogPost(blogPostID string, blogPost *BlogPostInput) (*BlogPost, error) {
+	return sendEntity[BlogPost](api, "UpdateBlogPost", "PUT", _V2_BASE+"/blogposts/"+blogPostID, blogPost, ErrNoContent)
+}
+
+// DeleteBlogPost moves blog post with given ID to the trash
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-id-delete
+func (api *APIv2) DeleteBlogPost(blogPostID string) error {
+	return deleteEntity(api, "DeleteBlogPost", _V2_BASE+"/blogposts/"+blogPostID, ErrNoContent)
+}
+
+// CreateFooterComment creates new footer comment
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-post
+func (api *APIv2) CreateFooterComment(comment *FooterCommentInput) (*FooterComment, error) {
+	return sendEntity[FooterComment](api, "CreateFooterComment", "POST", _V2_BASE+"/footer-comments", comment, ErrNoContent)
+}
+
+// UpdateFooterComment updates footer comment with given ID. Version number must
+// be incremented.
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-comment-id-put
+func (api *APIv2) UpdateFooterComment(commentID string, comment *FooterCommentInput) (*FooterComment, error) {
+	return sendEntity[FooterComment](api, "UpdateFooterComment", "PUT", _V2_BASE+"/footer-comments/"+commentID, comment, ErrNoComment)
+}
+
+// DeleteFooterComment deletes footer comment with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-comment-id-delete
+func (api *APIv2) DeleteFooterComment(commentID string) error {
+	return deleteEntity(api, "DeleteFooterComment", _V2_BASE+"/footer-comments/"+commentID, ErrNoComment)
+}
+
+// CreateInlineComment creates new inline comment
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-inline-comments-post
+func (api *APIv2) CreateInlineComment(comment *InlineCommentInput) (*InlineComment, error) {
+	return sendEntity[InlineComment](api, "CreateInlineComment", "POST", _V2_BASE+"/inline-comments", comment, ErrNoContent)
+}
+
+// UpdateInlineComment updates inline comment with given ID. Version number must
+// be incremented.
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-inline-comments-comment-id-put
+func (api *APIv2) UpdateInlineComment(commentID string, comment *InlineCommentInput) (*InlineComment, error) {
+	return sendEntity[InlineComment](api, "UpdateInlineComment", "PUT", _V2_BASE+"/inline-comments/"+commentID, comment, ErrNoComment)
+}
+
+// DeleteInlineComment deletes inline comment with given ID
+// https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-inline-comments-comment-id-delete
+func (api *APIv2) DeleteInlineComment(commentID string) error {
+	return deleteEntity(api, "DeleteInlineComment", _V2_BASE+"/inline-comments/"+commentID, ErrNoComment)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Pages returns iterator over all pages
+func (api *APIv2) Pages(params PageListParameters) iter.Seq2[*Page, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*Page], error) {
+		params.Cursor = cursor
+		return api.GetPages(params)
+	})
+}
+
+// SpacePages returns iterator over all pages in given space
+func (api *APIv2) SpacePages(spaceID string, params PageListParameters) iter.Seq2[*Page, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*Page], error) {
+		params.Cursor = cursor
+		return api.GetSpacePages(spaceID, params)
+	})
+}
+
+// PageChildren returns iterator over all direct children of given page
+func (api *APIv2) PageChildren(pageID string, params PageListParameters) iter.Seq2[*Page, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*Page], error) {
+		params.Cursor = cursor
+		return api.GetPageChildren(pageID, params)
+	})
+}
+
+// BlogPosts returns iterator over all blog posts
+func (api *APIv2) BlogPosts(params BlogPostListParameters) iter.Seq2[*BlogPost, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*BlogPost], error) {
+		params.Cursor = cursor
+		return api.GetBlogPosts(params)
+	})
+}
+
+// SpaceBlogPosts returns iterator over all blog posts in given space
+func (api *APIv2) SpaceBlogPosts(spaceID string, params BlogPostListParameters) iter.Seq2[*BlogPost, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*BlogPost], error) {
+		params.Cursor = cursor
+		return api.GetSpaceBlogPosts(spaceID, params)
+	})
+}
+
+// FooterComments returns iterator over all footer comments
+func (api *APIv2) FooterComments(params CommentListParameters) iter.Seq2[*FooterComment, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*FooterComment], error) {
+		params.Cursor = cursor
+		return api.GetFooterComments(params)
+	})
+}
+
+// InlineComments returns iterator over all inline comments
+func (api *APIv2) InlineComments(params CommentListParameters) iter.Seq2[*InlineComment, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*InlineComment], error) {
+		params.Cursor = cursor
+		return api.GetInlineComments(params)
+	})
+}
+
+// PageFooterComments returns iterator over all footer comments of given page
+func (api *APIv2) PageFooterComments(pageID string, params CommentListParameters) iter.Seq2[*FooterComment, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*FooterComment], error) {
+		params.Cursor = cursor
+		return api.GetPageFooterComments(pageID, params)
+	})
+}
+
+// PageInlineComments returns iterator over all inline comments of given page
+func (api *APIv2) PageInlineComments(pageID string, params CommentListParameters) iter.Seq2[*InlineComment, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*InlineComment], error) {
+		params.Cursor = cursor
+		return api.GetPageInlineComments(pageID, params)
+	})
+}
+
+// BlogPostFooterComments returns iterator over all footer comments of given
+// blog post
+func (api *APIv2) BlogPostFooterComments(blogPostID string, params CommentListParameters) iter.Seq2[*FooterComment, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*FooterComment], error) {
+		params.Cursor = cursor
+		return api.GetBlogPostFooterComments(blogPostID, params)
+	})
+}
+
+// BlogPostInlineComments returns iterator over all inline comments of given
+// blog post
+func (api *APIv2) BlogPostInlineComments(blogPostID string, params CommentListParameters) iter.Seq2[*InlineComment, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*InlineComment], error) {
+		params.Cursor = cursor
+		return api.GetBlogPostInlineComments(blogPostID, params)
+	})
+}
+
+// Spaces returns iterator over all spaces
+func (api *APIv2) Spaces(params SpaceListParameters) iter.Seq2[*SpaceV2, error] {
+	return iterateCursor(func(cursor string) (*CursorCollection[*SpaceV2], error) {
+		params.Cursor = cursor
+		return api.GetSpaces(params)
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NextCursor returns cursor for fetching next page of results
+func (c *CursorCollection[T]) NextCursor() string {
+	if c == nil || c.Links == nil || c.Links.Next == "" {
+		return ""
+	}
+
+	u, err := url.Parse(c.Links.Next)
+
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("cursor")
+}
+
+// HasNext returns true if there are more results
+func (c *CursorCollection[T]) HasNext() bool {
+	return c.NextCursor() != ""
+}
+
+// IsResolved returns true if inline comment is resolved
+func (c *InlineComment) IsResolved() bool {
+	return c.ResolutionStatus == RESOLUTION_STATUS_RESOLVED
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCursorCollection fetches collection with cursor-based pagination
+func getCursorCollection[T any](api *APIv2, operation, uri string, params Parameters, notFoundErr error) (*CursorCollection[T], error) {
+	result := &CursorCollection[T]{}
+	statusCode, err := api.api.doRequest(
+		operation, "GET", uri,
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = makeV2Error(statusCode, ErrQueryError, notFoundErr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getEntity fetches single entity
+func getEntity[T any](api *APIv2, operation, uri string, params Parameters, notFoundErr error) (*T, error) {
+	result := new(T)
+	statusCode, err := api.api.doRequest(
+		operation, "GET", uri,
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = makeV2Error(statusCode, ErrQueryError, notFoundErr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// sendEntity sends entity to API and decodes result
+func sendEntity[T any](api *APIv2, operation, method, uri string, body any, notFoundErr error) (*T, error) {
+	result := new(T)
+	statusCode, err := api.api.doRequest(
+		operation, method, uri,
+		emptyParams, result, body,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = makeV2Error(statusCode, ErrBadRequest, notFoundErr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// deleteEntity deletes entity
+func deleteEntity(api *APIv2, operation, uri string, notFoundErr error) error {
+	statusCode, err := api.api.doRequest(
+		operation, "DELETE", uri,
+		emptyParams, nil, nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return makeV2Error(statusCode, ErrBadRequest, notFoundErr)
+}
+
+// iterateCursor creates iterator over all results of collection with cursor-based
+// pagination
+func iterateCursor[T any](fetch func(cursor string) (*CursorCollection[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var cursor string
+
+		for {
+			collection, err := fetch(cursor)
+
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range collection.Results {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			cursor = collection.NextCursor()
+
+			if cursor == "" {
+				return
+			}
+		}
+	}
+}
+
+// makeV2Error converts status code to error. Given errors are returned for
+// requests with invalid data and for missing entities.
+func makeV2Error(statusCode int, badRequestErr, notFoundErr error) error {
+	switch statusCode {
+	case 200, 201, 204:
+		return nil
+	case 400:
+		return badRequestErr
+	case 401, 403:
+		return ErrNoPerms
+	case 404:
+		return notFoundErr
+	case 409:
+		return ErrVersionConflict
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates parameters
+func (p PageListParameters) Validate() error {
+	return validateV2Limit(p.Limit)
+}
+
+// Validate validates parameters
+func (p PageParameters) Validate() error {
+	return nil
+}
+
+// Validate validates parameters
+func (p BlogPostListParameters) Validate() error {
+	return validateV2Limit(p.Limit)
+}
+
+// Validate validates parameters
+func (p BlogPostParameters) Validate() error {
+	return nil
+}
+
+// Validate validates parameters
+func (p CommentListParameters) Validate() error {
+	return validateV2Limit(p.Limit)
+}
+
+// Validate validates parameters
+func (p CommentParameters) Validate() error {
+	return nil
+}
+
+// Validate validates parameters
+func (p SpaceListParameters) Validate() error {
+	return validateV2Limit(p.Limit)
+}
+
+// Validate validates parameters
+func (p DescriptionParameters) Validate() error {
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ToQuery convert params to URL query
+func (p PageListParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p PageParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p BlogPostListParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p BlogPostParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p CommentListParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p CommentParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p SpaceListParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ToQuery convert params to URL query
+func (p DescriptionParameters) ToQuery() string {
+	return paramsToQuery(p)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// validateV2Limit validates limit of v2 collection
+func validateV2Limit(limit int) error {
+	if limit < 0 || limit > 250 {
+		return errors.New("Limit must be between 1 and 250")
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Audit record field names
+const (
+	AUDIT_FIELD_TIMESTAMP    = "timestamp"
+	AUDIT_FIELD_USER_NAME    = "user_name"
+	AUDIT_FIELD_USER_KEY     = "user_key"
+	AUDIT_FIELD_USER_ID      = "user_id"
+	AUDIT_FIELD_DISPLAY_NAME = "user_display_name"
+	AUDIT_FIELD_SOURCE_IP    = "source_ip"
+	AUDIT_FIELD_CATEGORY     = "category"
+	AUDIT_FIELD_ACTION       = "action"
+	AUDIT_FIELD_MESSAGE      = "message"
+	AUDIT_FIELD_IS_SYSADMIN  = "is_sysadmin"
+)
+
+// _SYSLOG_FACILITY_LOG_AUDIT is syslog facility "log audit"
+const _SYSLOG_FACILITY_LOG_AUDIT = 13
+
+// _SYSLOG_SEVERITY_NOTICE is syslog severity "notice"
+const _SYSLOG_SEVERITY_NOTICE = 5
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AuditWriter is audit records writer
+type AuditWriter interface {
+	// Write writes audit record
+	Write(record *AuditRecord) error
+
+	// Flush writes any buffered data
+	Flush() error
+}
+
+// CEFParameters is params for CEF writer
+type CEFParameters struct {
+	Vendor   string // Device vendor (Atlassian by default)
+	Product  string // Device product (Confluence by default)
+	Version  string // Device version
+	Hostname string // Hostname used in syslog header (system hostname by default)
+	AppName  string // Application name used in syslog header (confluence by default)
+	Facility int    // Syslog facility (log audit (13) is used if 0)
+	Severity int    // CEF severity from 0 to 10 (3 by default, 7 for sysadmin actions)
+}
+
+// jsonlAuditWriter writes audit records as JSON Lines
+type jsonlAuditWriter struct {
+	encoder *json.Encoder
+}
+
+// csvAuditWriter writes audit records as CSV
+type csvAuditWriter struct {
+	writer        *csv.Writer
+	columns       []string
+	headerWritten bool
+}
+
+// cefAuditWriter writes audit records as CEF syslog messages
+type cefAuditWriter struct {
+	w      io.Writer
+	params CEFParameters
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AuditFields is list of all audit record fields in default order
+var AuditFields = []string{
+	AUDIT_FIELD_TIMESTAMP,
+	AUDIT_FIELD_USER_NAME,
+	AUDIT_FIELD_USER_KEY,
+	AUDIT_FIELD_USER_ID,
+	AUDIT_FIELD_DISPLAY_NAME,
+	AUDIT_FIELD_SOURCE_IP,
+	AUDIT_FIELD_CATEGORY,
+	AUDIT_FIELD_ACTION,
+	AUDIT_FIELD_MESSAGE,
+	AUDIT_FIELD_IS_SYSADMIN,
+}
+
+// cefHeaderEscaper escapes CEF header values
+var cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+
+// cefExtensionEscaper escapes CEF extension values
+var cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExportAuditRecords fetches all audit records matching given params and writes
+// them using given writer. All pages of records are fetched automatically.
+func (api *API) ExportAuditRecords(params AuditParameters, w AuditWriter) (int, error) {
+	var count int
+
+	if params.Limit <= 0 {
+		params.Limit = _AUDIT_PAGE_SIZE
+	}
+
+	for {
+		records, err := api.GetAuditRecords(params)
+
+		if err != nil {
+			return count, err
+		}
+
+		for _, record := range records.Results {
+			err = w.Write(record)
+
+			if err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if isLastPage(records.Size, records.Limit, params.Limit) {
+			break
+		}
+
+		params.Start += records.Size
+	}
+
+	return count, w.Flush()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewJSONLAuditWriter creates new writer for audit records in JSON Lines format.
+// Every record is written as JSON object with standard field names.
+func NewJSONLAuditWriter(w io.Writer) AuditWriter {
+	return &jsonlAuditWriter{json.NewEncoder(w)}
+}
+
+// NewCSVAuditWriter creates new writer for audit records in CSV format. If no
+// columns are given, all fields are written.
+func NewCSVAuditWriter(w io.Writer, columns ...string) AuditWriter {
+	if len(columns) == 0 {
+		columns = AuditFields
+	}
+
+	return &csvAuditWriter{writer: csv.NewWriter(w), columns: columns}
+}
+
+// NewCEFAuditWriter creates new writer for audit records in CEF format with
+// RFC 5424 syslog header. Every message is terminated by new line, so writer can
+// be used for files and for TCP and UDP syslog connections.
+func NewCEFAuditWriter(w io.Writer, params CEFParameters) AuditWriter {
+	if params.Vendor == "" {
+		params.Vendor = "Atlassian"
+	}
+
+	if params.Product == "" {
+		params.Product = "Confluence"
+	}
+
+	if params.AppName == "" {
+		params.AppName = "confluence"
+	}
+
+	if params.Hostname == "" {
+		params.Hostname, _ = os.Hostname()
+	}
+
+	if params.Hostname == "" {
+		params.Hostname = "-"
+	}
+
+	if params.Facility == 0 {
+		params.Facility = _SYSLOG_FACILITY_LOG_AUDIT
+	}
+
+	return &cefAuditWriter{w: w, params: params}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetField returns value of audit record field with given name
+func (r *AuditRecord) GetField(name string) string {
+	switch name {
+	case AUDIT_FIELD_TIMESTAMP:
+		if r.CreationDate == nil {
+			return ""
+		}
+
+		return r.CreationDate.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	case AUDIT_FIELD_USER_NAME, AUDIT_FIELD_USER_KEY, AUDIT_FIELD_USER_ID, AUDIT_FIELD_DISPLAY_NAME:
+		return getAuditUserField(r.Author, name)
+	case AUDIT_FIELD_SOURCE_IP:
+		return r.RemoteAddress
+	case AUDIT_FIELD_CATEGORY:
+		return r.Category
+	case AUDIT_FIELD_ACTION:
+		return r.Summary
+	case AUDIT_FIELD_MESSAGE:
+		return r.Description
+	case AUDIT_FIELD_IS_SYSADMIN:
+		return strconv.FormatBool(r.IsSysAdmin)
+	}
+
+	return ""
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes audit record
+func (w *jsonlAuditWriter) Write(record *AuditRecord) error {
+	data := map[string]any{}
+
+	for _, field := range AuditFields {
+		value := record.GetField(field)
+
+		switch {
+		case field == AUDIT_FIELD_IS_SYSADMIN:
+			data[field] = record.IsSysAdmin
+		case value != "":
+			data[field] = value
+		}
+	}
+
+	return w.encoder.Encode(data)
+}
+
+// Flush writes any buffered data
+func (w *jsonlAuditWriter) Flush() error {
+	return nil
+}
+
+// Write writes audit record
+func (w *csvAuditWriter) Write(record *AuditRecord) error {
+	err := w.writeHeader()
+
+	if err != nil {
+		return err
+	}
+
+	row := make([]string, len(w.columns))
+
+	for i, column := range w.columns {
+		row[i] = record.GetField(column)
+	}
+
+	return w.writer.Write(row)
+}
+
+// Flush writes any buffered data
+func (w *csvAuditWriter) Flush() error {
+	err := w.writeHeader()
+
+	if err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// writeHeader writes CSV header if it wasn't written before
+func (w *csvAuditWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+
+	w.headerWritten = true
+
+	return w.writer.Write(w.columns)
+}
+
+// Write writes audit record
+func (w *cefAuditWriter) Write(record *AuditRecord) error {
+	p := w.params
+	severity := p.Severity
+
+	if severity == 0 {
+		severity = 3
+
+		if record.IsSysAdmin {
+			severity = 7
+		}
+	}
+
+	timestamp := time.Now()
+
+	if record.CreationDate != nil {
+		timestamp = record.CreationDate.Time
+	}
+
+	signatureID := record.Category
+
+	if signatureID == "" {
+		signatureID = "audit"
+	}
+
+	var ext []string
+
+	addExt := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtensionEscaper.Replace(value))
+		}
+	}
+
+	addExt("rt", strconv.FormatInt(timestamp.UnixMilli(), 10))
+	addExt("suser", record.GetField(AUDIT_FIELD_USER_NAME))
+	addExt("suid", record.GetField(AUDIT_FIELD_USER_KEY))
+	addExt("src", record.RemoteAddress)
+	addExt("cat", record.Category)
+	addExt("act", record.Summary)
+	addExt("msg", record.Description)
+	addExt("cs1Label", "sysAdmin")
+	addExt("cs1", strconv.FormatBool(record.IsSysAdmin))
+
+	_, err := fmt.Fprintf(
+		w.w, "<%d>1 %s %s %s - - - CEF:0|%s|%s|%s|%s|%s|%d|%s\n",
+		p.Facility*8+_SYSLOG_SEVERITY_NOTICE,
+		timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		p.Hostname, p.AppName,
+		cefHeaderEscaper.Replace(p.Vendor),
+		cefHeaderEscaper.Replace(p.Product),
+		cefHeaderEscaper.Replace(p.Version),
+		cefHeaderEscaper.Replace(signatureID),
+		cefHeaderEscaper.Replace(record.Summary),
+		severity, strings.Join(ext, " "),
+	)
+
+	return err
+}
+
+// Flush writes any buffered data
+func (w *cefAuditWriter) Flush() error {
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getAuditUserField returns value of user field
+func getAuditUserField(user *User, name string) string {
+	if user == nil {
+		return ""
+	}
+
+	switch name {
+	case AUDIT_FIELD_USER_NAME:
+		return user.Name
+	case AUDIT_FIELD_USER_KEY:
+		return user.Key
+	case AUDIT_FIELD_USER_ID:
+		return user.AccountID
+	case AUDIT_FIELD_DISPLAY_NAME:
+		return user.DisplayName
+	}
+
+	return ""
+}
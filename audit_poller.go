@@ -0,0 +1,392 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _AUDIT_POLL_INTERVAL is default audit log polling interval
+const _AUDIT_POLL_INTERVAL = time.Minute
+
+// _AUDIT_PAGE_SIZE is number of audit records fetched with one request
+const _AUDIT_PAGE_SIZE = 1000
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AuditPollerParameters is params for audit log poller
+type AuditPollerParameters struct {
+	// StateFile is path to file used for storing checkpoint. If empty, checkpoint
+	// is kept only in memory.
+	StateFile string
+
+	// Since is time of the first record delivered if there is no saved checkpoint
+	// (current time is used if zero)
+	Since time.Time
+
+	// Interval is polling interval (1 minute by default)
+	Interval time.Duration
+
+	// SearchString is string used for filtering records
+	SearchString string
+
+	// PageSize is number of records fetched with one request (1000 is used if 0)
+	PageSize int
+
+	// OnGap is called if checkpoint is older than audit log retention period, so
+	// records between checkpoint and retention boundary could be lost
+	OnGap func(checkpoint, boundary time.Time)
+
+	// OnError is called for polling errors. If set, polling continues after
+	// errors, otherwise polling is stopped on the first error.
+	OnError func(err error)
+}
+
+// AuditHandler is function called for every new audit record
+type AuditHandler func(record *AuditRecord) error
+
+// AuditPollerState contains audit poller checkpoint
+type AuditPollerState struct {
+	Checkpoint time.Time `json:"checkpoint"`
+	Seen       []string  `json:"seen,omitempty"` // IDs of delivered records created at checkpoint
+}
+
+// AuditPoller continuously delivers new audit records
+type AuditPoller struct {
+	api    *API
+	params AuditPollerParameters
+	state  *AuditPollerState
+	mu     sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewAuditPoller creates new audit log poller. If state file exists, checkpoint is
+// loaded from it.
+func (api *API) NewAuditPoller(params AuditPollerParameters) (*AuditPoller, error) {
+	if params.Interval <= 0 {
+		params.Interval = _AUDIT_POLL_INTERVAL
+	}
+
+	if params.PageSize <= 0 {
+		params.PageSize = _AUDIT_PAGE_SIZE
+	}
+
+	state, err := readAuditPollerState(params.StateFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Checkpoint.IsZero() {
+		state.Checkpoint = params.Since
+
+		if state.Checkpoint.IsZero() {
+			state.Checkpoint = time.Now()
+		}
+	}
+
+	return &AuditPoller{api: api, params: params, state: state}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Poll fetches records created since checkpoint and calls fn for every record in
+// chronological order. Records sharing creation date with checkpoint are
+// delivered only once. If fn returns error, polling is stopped and the record
+// will be delivered again by the next poll.
+func (p *AuditPoller) Poll(fn AuditHandler) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := p.checkRetention()
+
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := p.fetch()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var delivered int
+	var occurrences map[string]int
+
+	for i, record := range records {
+		date := record.CreationDate.Time
+
+		if i == 0 || !date.Equal(records[i-1].CreationDate.Time) {
+			occurrences = map[string]int{}
+		}
+
+		id := getAuditRecordID(record, occurrences)
+
+		if date.Equal(p.state.Checkpoint) && slices.Contains(p.state.Seen, id) {
+			continue
+		}
+
+		err = fn(record)
+
+		if err != nil {
+			break
+		}
+
+		if date.Equal(p.state.Checkpoint) {
+			p.state.Seen = append(p.state.Seen, id)
+		} else {
+			p.state.Checkpoint, p.state.Seen = date, []string{id}
+		}
+
+		delivered++
+	}
+
+	saveErr := p.save()
+
+	if err != nil {
+		return delivered, err
+	}
+
+	return delivered, saveErr
+}
+
+// Run polls audit log with configured interval until context is canceled
+func (p *AuditPoller) Run(ctx context.Context, fn AuditHandler) error {
+	ticker := time.NewTicker(p.params.Interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := p.Poll(fn)
+
+		switch {
+		case err != nil && p.params.OnError != nil:
+			p.params.OnError(err)
+		case err != nil:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Records starts polling and returns channel with new records. Both channels are
+// closed when polling is stopped, error channel contains error which stopped
+// polling.
+func (p *AuditPoller) Records(ctx context.Context, buffer int) (<-chan *AuditRecord, <-chan error) {
+	records := make(chan *AuditRecord, max(buffer, 0))
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(records)
+		defer close(errs)
+
+		err := p.Run(ctx, func(record *AuditRecord) error {
+			select {
+			case records <- record:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil && !errors.Is(err, context.Canceled) {
+			errs <- err
+		}
+	}()
+
+	return records, errs
+}
+
+// Checkpoint returns creation date of the last delivered record
+func (p *AuditPoller) Checkpoint() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.state.Checkpoint
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkRetention moves checkpoint to retention boundary if checkpoint is older
+func (p *AuditPoller) checkRetention() error {
+	retention, err := p.api.GetAuditRetention()
+
+	if err != nil {
+		return fmt.Errorf("Can't fetch audit retention period: %w", err)
+	}
+
+	boundary := getAuditRetentionBoundary(retention, time.Now())
+
+	if boundary.IsZero() || !p.state.Checkpoint.Before(boundary) {
+		return nil
+	}
+
+	if p.params.OnGap != nil {
+		p.params.OnGap(p.state.Checkpoint, boundary)
+	}
+
+	p.state.Checkpoint, p.state.Seen = boundary, nil
+
+	return nil
+}
+
+// fetch fetches all records created since checkpoint sorted by creation date
+func (p *AuditPoller) fetch() ([]*AuditRecord, error) {
+	var result []*AuditRecord
+
+	// API uses dates without time, so interval starts from the checkpoint day
+	// and ends a day after now to avoid time zone issues
+	params := AuditParameters{
+		StartDate:    p.state.Checkpoint.UTC(),
+		EndDate:      time.Now().UTC().Add(24 * time.Hour),
+		SearchString: p.params.SearchString,
+		Limit:        p.params.PageSize,
+	}
+
+	for {
+		records, err := p.api.GetAuditRecords(params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records.Results {
+			if record.CreationDate != nil && !record.CreationDate.Before(p.state.Checkpoint) {
+				result = append(result, record)
+			}
+		}
+
+		if isLastPage(records.Size, records.Limit, params.Limit) {
+			break
+		}
+
+		params.Start += records.Size
+	}
+
+	// API returns newest records first
+	slices.Reverse(result)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreationDate.Before(result[j].CreationDate.Time)
+	})
+
+	return result, nil
+}
+
+// save saves poller state to state file
+func (p *AuditPoller) save() error {
+	if p.params.StateFile == "" {
+		return nil
+	}
+
+	return writeJSONFile(p.params.StateFile, p.state)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readAuditPollerState reads audit poller state from given file
+func readAuditPollerState(file string) (*AuditPollerState, error) {
+	state := &AuditPollerState{}
+
+	if file == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(file)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return state, nil
+	case err != nil:
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, state)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode audit poller state: %w", err)
+	}
+
+	return state, nil
+}
+
+// getAuditRetentionBoundary returns date of the oldest record available in
+// audit log. Zero time is returned if retention period is unknown.
+func getAuditRetentionBoundary(retention *AuditRetentionInfo, now time.Time) time.Time {
+	n := retention.Number
+
+	if n <= 0 {
+		return time.Time{}
+	}
+
+	switch strings.ToLower(retention.Units) {
+	case UNITS_MINUTES:
+		return now.Add(-time.Duration(n) * time.Minute)
+	case UNITS_HOURS:
+		return now.Add(-time.Duration(n) * time.Hour)
+	case UNITS_DAYS:
+		return now.AddDate(0, 0, -n)
+	case UNITS_MONTHS:
+		return now.AddDate(0, -n, 0)
+	case UNITS_YEARS:
+		return now.AddDate(-n, 0, 0)
+	}
+
+	return time.Time{}
+}
+
+// getAuditRecordHash returns hash used for records de-duplication
+func getAuditRecordHash(record *AuditRecord) string {
+	author := ""
+
+	if record.Author != nil {
+		author = record.Author.Key + "/" + record.Author.Name + "/" + record.Author.AccountID
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		record.CreationDate.Format(time.RFC3339Nano), author, record.RemoteAddress,
+		record.Category, record.Summary, record.Description,
+	}, "\x00")))
+
+	return hex.EncodeToString(hash[:8])
+}
+
+// getAuditRecordID returns identity of record which stays the same between polls.
+// Identical records created at the same time are distinguished by the number of
+// the occurrence.
+func getAuditRecordID(record *AuditRecord, occurrences map[string]int) string {
+	hash := getAuditRecordHash(record)
+	n := occurrences[hash]
+
+	occurrences[hash]++
+
+	if n == 0 {
+		return hash
+	}
+
+	return hash + "#" + strconv.Itoa(n)
+}
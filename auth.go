@@ -10,6 +10,9 @@ package confluence
 import (
 	"encoding/base64"
 	"errors"
+	"strings"
+
+	"github.com/valyala/fasthttp"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -20,6 +23,15 @@ type Auth interface {
 	Encode() string
 }
 
+// Authorizer is interface for authorization methods which must provide fresh
+// authorization data for every request (e.g. expiring tokens or signatures)
+type Authorizer interface {
+	Auth
+
+	// Authorize adds authorization data to the request
+	Authorize(req *fasthttp.Request) error
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // AuthBasic is struct with data for basic authorization
@@ -33,6 +45,13 @@ type AuthToken struct {
 	Token string
 }
 
+// AuthCloud is struct with data for Confluence Cloud authorization with email
+// and API token
+type AuthCloud struct {
+	Email string
+	Token string
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var (
@@ -40,6 +59,8 @@ var (
 	ErrEmptyPassword    = errors.New("Password can't be empty")
 	ErrEmptyToken       = errors.New("Token can't be empty")
 	ErrTokenWrongLength = errors.New("Token length must be equal to 44")
+	ErrEmptyEmail       = errors.New("Email can't be empty")
+	ErrInvalidEmail     = errors.New("Email must be a valid email address")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -77,3 +98,22 @@ func (a AuthToken) Validate() error {
 func (a AuthToken) Encode() string {
 	return "Bearer " + a.Token
 }
+
+// Validate validates authorization data
+func (a AuthCloud) Validate() error {
+	switch {
+	case a.Email == "":
+		return ErrEmptyEmail
+	case !strings.Contains(a.Email, "@"):
+		return ErrInvalidEmail
+	case a.Token == "":
+		return ErrEmptyToken
+	}
+
+	return nil
+}
+
+// Encode encodes data for authorization
+func (a AuthCloud) Encode() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Email+":"+a.Token))
+}
@@ -0,0 +1,365 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Application link OAuth endpoints
+const (
+	_OAUTH1_REQUEST_TOKEN_PATH = "/plugins/servlet/oauth/request-token"
+	_OAUTH1_AUTHORIZE_PATH     = "/plugins/servlet/oauth/authorize"
+	_OAUTH1_ACCESS_TOKEN_PATH  = "/plugins/servlet/oauth/access-token"
+)
+
+// _OAUTH1_OOB is callback value for out-of-band verification
+const _OAUTH1_OOB = "oob"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AuthOAuth1 is OAuth 1.0a authorization with RSA-SHA1 signatures used by
+// Confluence application links
+type AuthOAuth1 struct {
+	ConsumerKey string
+	PrivateKey  *rsa.PrivateKey
+
+	// Token is access token
+	Token string
+
+	// Client is HTTP client used for token requests
+	Client *fasthttp.Client
+}
+
+// oauth1Param is OAuth parameter
+type oauth1Param struct {
+	Name  string
+	Value string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyConsumerKey = errors.New("Consumer key can't be empty")
+	ErrEmptyPrivateKey  = errors.New("Private key can't be empty")
+	ErrEmptyOAuthToken  = errors.New("Access token can't be empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParsePrivateKey parses PEM encoded RSA private key in PKCS #1 or PKCS #8 format
+func ParsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+
+	if block == nil {
+		return nil, errors.New("Can't decode PEM data")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+
+	if err == nil {
+		return key, nil
+	}
+
+	pkcs8Key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse private key: %w", err)
+	}
+
+	key, ok := pkcs8Key.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, errors.New("Private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates authorization data
+func (a *AuthOAuth1) Validate() error {
+	switch {
+	case a.ConsumerKey == "":
+		return ErrEmptyConsumerKey
+	case a.PrivateKey == nil:
+		return ErrEmptyPrivateKey
+	case a.Token == "":
+		return ErrEmptyOAuthToken
+	}
+
+	return nil
+}
+
+// Encode returns empty string because every request must be signed separately
+func (a *AuthOAuth1) Encode() string {
+	return ""
+}
+
+// Authorize signs the request and adds authorization header to it
+func (a *AuthOAuth1) Authorize(req *fasthttp.Request) error {
+	header, err := a.sign(req, a.Token, nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", header)
+
+	return nil
+}
+
+// RequestToken fetches temporary request token from Confluence with given URL.
+// If callbackURL is empty, out-of-band verification will be used.
+func (a *AuthOAuth1) RequestToken(confluenceURL, callbackURL string) (string, error) {
+	if callbackURL == "" {
+		callbackURL = _OAUTH1_OOB
+	}
+
+	values, err := a.requestToken(
+		confluenceURL+_OAUTH1_REQUEST_TOKEN_PATH, "",
+		[]oauth1Param{{"oauth_callback", callbackURL}},
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return values.Get("oauth_token"), nil
+}
+
+// AuthorizeURL returns URL of the page where user must approve access for
+// given request token
+func (a *AuthOAuth1) AuthorizeURL(confluenceURL, requestToken string) string {
+	return confluenceURL + _OAUTH1_AUTHORIZE_PATH + "?oauth_token=" + url.QueryEscape(requestToken)
+}
+
+// AccessToken exchanges approved request token and verifier for access token.
+// Access token will be also set as current token.
+func (a *AuthOAuth1) AccessToken(confluenceURL, requestToken, verifier string) (string, error) {
+	var extra []oauth1Param
+
+	if verifier != "" {
+		extra = append(extra, oauth1Param{"oauth_verifier", verifier})
+	}
+
+	values, err := a.requestToken(
+		confluenceURL+_OAUTH1_ACCESS_TOKEN_PATH, requestToken, extra,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	a.Token = values.Get("oauth_token")
+
+	return a.Token, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// requestToken sends signed request to token endpoint
+func (a *AuthOAuth1) requestToken(endpoint, token string, extra []oauth1Param) (url.Values, error) {
+	switch {
+	case a.ConsumerKey == "":
+		return nil, ErrEmptyConsumerKey
+	case a.PrivateKey == nil:
+		return nil, ErrEmptyPrivateKey
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(endpoint)
+	req.Header.SetMethod("POST")
+
+	header, err := a.sign(req, token, extra)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", header)
+
+	err = a.getClient().Do(req, resp)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't send token request: %w", err)
+	}
+
+	values, err := url.ParseQuery(string(resp.Body()))
+
+	switch {
+	case resp.StatusCode() != 200 && err == nil && values.Get("oauth_problem") != "":
+		return nil, fmt.Errorf("Can't get token: %s", values.Get("oauth_problem"))
+	case resp.StatusCode() != 200:
+		return nil, fmt.Errorf("Can't get token: %w", makeUnknownError(resp.StatusCode()))
+	case err != nil:
+		return nil, fmt.Errorf("Can't decode token response: %w", err)
+	case values.Get("oauth_token") == "":
+		return nil, errors.New("Can't get token: token endpoint returned empty token")
+	}
+
+	return values, nil
+}
+
+// sign creates authorization header with signature for given request
+func (a *AuthOAuth1) sign(req *fasthttp.Request, token string, extra []oauth1Param) (string, error) {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+
+	if err != nil {
+		return "", err
+	}
+
+	oauthParams := []oauth1Param{
+		{"oauth_consumer_key", a.ConsumerKey},
+		{"oauth_nonce", hex.EncodeToString(nonce)},
+		{"oauth_signature_method", "RSA-SHA1"},
+		{"oauth_timestamp", strconv.FormatInt(time.Now().Unix(), 10)},
+		{"oauth_version", "1.0"},
+	}
+
+	if token != "" {
+		oauthParams = append(oauthParams, oauth1Param{"oauth_token", token})
+	}
+
+	oauthParams = append(oauthParams, extra...)
+
+	params := append([]oauth1Param{}, oauthParams...)
+
+	req.URI().QueryArgs().VisitAll(func(k, v []byte) {
+		params = append(params, oauth1Param{string(k), string(v)})
+	})
+
+	baseString := getOAuth1BaseString(
+		string(req.Header.Method()), getOAuth1BaseURL(req.URI()), params,
+	)
+
+	hash := sha1.Sum([]byte(baseString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.PrivateKey, crypto.SHA1, hash[:])
+
+	if err != nil {
+		return "", fmt.Errorf("Can't sign request: %w", err)
+	}
+
+	oauthParams = append(oauthParams, oauth1Param{
+		"oauth_signature", base64.StdEncoding.EncodeToString(signature),
+	})
+
+	var header strings.Builder
+
+	header.WriteString("OAuth ")
+
+	for i, p := range oauthParams {
+		if i > 0 {
+			header.WriteString(", ")
+		}
+
+		header.WriteString(oauth1Escape(p.Name) + `="` + oauth1Escape(p.Value) + `"`)
+	}
+
+	return header.String(), nil
+}
+
+// getClient returns HTTP client for token requests
+func (a *AuthOAuth1) getClient() *fasthttp.Client {
+	if a.Client == nil {
+		a.Client = &fasthttp.Client{
+			Name:         getUserAgent("", ""),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+		}
+	}
+
+	return a.Client
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getOAuth1BaseString creates signature base string (RFC 5849, section 3.4.1)
+func getOAuth1BaseString(method, baseURL string, params []oauth1Param) string {
+	encoded := make([]string, 0, len(params))
+
+	for _, p := range params {
+		encoded = append(encoded, oauth1Escape(p.Name)+"="+oauth1Escape(p.Value))
+	}
+
+	sort.Strings(encoded)
+
+	return strings.ToUpper(method) + "&" +
+		oauth1Escape(baseURL) + "&" +
+		oauth1Escape(strings.Join(encoded, "&"))
+}
+
+// getOAuth1BaseURL returns base string URI (RFC 5849, section 3.4.1.2)
+func getOAuth1BaseURL(uri *fasthttp.URI) string {
+	scheme := strings.ToLower(string(uri.Scheme()))
+	host := strings.ToLower(string(uri.Host()))
+
+	switch {
+	case scheme == "http" && strings.HasSuffix(host, ":80"),
+		scheme == "https" && strings.HasSuffix(host, ":443"):
+		host = host[:strings.LastIndex(host, ":")]
+	}
+
+	// Path is used in the form it is sent over the wire, so escaped segments
+	// (e.g. group names with spaces) are signed as is
+	path := string(uri.RequestURI())
+
+	if strings.Contains(path, "?") {
+		path = path[:strings.Index(path, "?")]
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	return scheme + "://" + host + path
+}
+
+// oauth1Escape percent-encodes string (RFC 5849, section 3.6)
+func oauth1Escape(s string) string {
+	var result strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			result.WriteByte(c)
+		default:
+			fmt.Fprintf(&result, "%%%02X", c)
+		}
+	}
+
+	return result.String()
+}
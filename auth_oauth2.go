@@ -0,0 +1,323 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OAUTH2_TOKEN_URL is default Atlassian OAuth 2.0 token endpoint
+const OAUTH2_TOKEN_URL = "https://auth.atlassian.com/oauth/token"
+
+// _OAUTH2_EXPIRY_DELTA is time before token expiry when token considered expired
+const _OAUTH2_EXPIRY_DELTA = 30 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AuthOAuth2 is OAuth 2.0 (3LO) authorization with automatic access token refresh.
+// AuthOAuth2 is safe for concurrent use.
+type AuthOAuth2 struct {
+	ClientID     string
+	ClientSecret string
+
+	// TokenURL is token endpoint URL (OAUTH2_TOKEN_URL is used if empty)
+	TokenURL string
+
+	// OnRefresh is callback executed after every token refresh. It can be used
+	// for persisting new tokens. New token is used even if callback returns
+	// error, because previous refresh token may be already revoked by rotation.
+	// Callback is executed without holding token lock, so it can call Token or
+	// Encode, but it must not call Refresh or Exchange.
+	OnRefresh func(token OAuth2Token) error
+
+	// OnError is called for every error returned by OnRefresh callback
+	OnError func(err error)
+
+	// Client is HTTP client used for token requests
+	Client *fasthttp.Client
+
+	token OAuth2Token
+	mu    sync.Mutex
+
+	// refreshMu serializes token requests
+	refreshMu sync.Mutex
+}
+
+// OAuth2Token contains OAuth 2.0 tokens
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// oauth2TokenResponse is token endpoint response
+type oauth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// oauth2TokenRequest is token endpoint request
+type oauth2TokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Code         string `json:"code,omitempty"`
+	RedirectURI  string `json:"redirect_uri,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyClientID     = errors.New("Client ID can't be empty")
+	ErrEmptyClientSecret = errors.New("Client secret can't be empty")
+	ErrEmptyAccessToken  = errors.New("Access token or refresh token must be set")
+	ErrNoRefreshToken    = errors.New("Access token is expired and there is no refresh token")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewAuthOAuth2 creates new OAuth 2.0 authorization with given client credentials
+// and tokens
+func NewAuthOAuth2(clientID, clientSecret string, token OAuth2Token) *AuthOAuth2 {
+	return &AuthOAuth2{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		token:        token,
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates authorization data
+func (a *AuthOAuth2) Validate() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch {
+	case a.ClientID == "":
+		return ErrEmptyClientID
+	case a.ClientSecret == "":
+		return ErrEmptyClientSecret
+	case a.token.AccessToken == "" && a.token.RefreshToken == "":
+		return ErrEmptyAccessToken
+	}
+
+	return nil
+}
+
+// Encode encodes data for authorization using current access token
+func (a *AuthOAuth2) Encode() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return "Bearer " + a.token.AccessToken
+}
+
+// Authorize adds authorization header with valid access token to the request.
+// Access token will be refreshed if it's expired.
+func (a *AuthOAuth2) Authorize(req *fasthttp.Request) error {
+	token, err := a.Token()
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	return nil
+}
+
+// Token returns valid token refreshing it if required
+func (a *AuthOAuth2) Token() (OAuth2Token, error) {
+	token := a.getToken()
+
+	if token.AccessToken != "" && !token.IsExpired() {
+		return token, nil
+	}
+
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	// Token may be already refreshed by another goroutine
+	token = a.getToken()
+
+	if token.AccessToken != "" && !token.IsExpired() {
+		return token, nil
+	}
+
+	return a.refresh(token)
+}
+
+// Refresh forcibly refreshes access token
+func (a *AuthOAuth2) Refresh() error {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	_, err := a.refresh(a.getToken())
+
+	return err
+}
+
+// Exchange exchanges authorization code for tokens
+func (a *AuthOAuth2) Exchange(code, redirectURI string) error {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	_, err := a.requestToken(oauth2TokenRequest{
+		GrantType:   "authorization_code",
+		Code:        code,
+		RedirectURI: redirectURI,
+	}, a.getToken())
+
+	return err
+}
+
+// IsExpired returns true if access token is expired or will expire soon
+func (t OAuth2Token) IsExpired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(_OAUTH2_EXPIRY_DELTA).After(t.Expiry)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getToken returns copy of current token
+func (a *AuthOAuth2) getToken() OAuth2Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.token
+}
+
+// refresh refreshes access token using refresh token from given token
+func (a *AuthOAuth2) refresh(current OAuth2Token) (OAuth2Token, error) {
+	if current.RefreshToken == "" {
+		return OAuth2Token{}, ErrNoRefreshToken
+	}
+
+	return a.requestToken(oauth2TokenRequest{
+		GrantType:    "refresh_token",
+		RefreshToken: current.RefreshToken,
+	}, current)
+}
+
+// requestToken sends request to token endpoint, updates tokens and executes
+// refresh callback. Must be called with refreshMu locked.
+func (a *AuthOAuth2) requestToken(tokenReq oauth2TokenRequest, current OAuth2Token) (OAuth2Token, error) {
+	tokenReq.ClientID = a.ClientID
+	tokenReq.ClientSecret = a.ClientSecret
+
+	reqData, err := json.Marshal(tokenReq)
+
+	if err != nil {
+		return OAuth2Token{}, err
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(a.getTokenURL())
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/json")
+	req.SetBody(reqData)
+
+	err = a.getClient().Do(req, resp)
+
+	if err != nil {
+		return OAuth2Token{}, fmt.Errorf("Can't send token request: %w", err)
+	}
+
+	tokenResp := &oauth2TokenResponse{}
+	err = json.Unmarshal(resp.Body(), tokenResp)
+
+	switch {
+	case resp.StatusCode() != 200 && tokenResp.Error != "":
+		return OAuth2Token{}, fmt.Errorf("Can't get token: %s (%s)", tokenResp.Error, tokenResp.Description)
+	case resp.StatusCode() != 200:
+		return OAuth2Token{}, fmt.Errorf("Can't get token: %w", makeUnknownError(resp.StatusCode()))
+	case err != nil:
+		return OAuth2Token{}, fmt.Errorf("Can't decode token response: %w", err)
+	case tokenResp.AccessToken == "":
+		return OAuth2Token{}, errors.New("Can't get token: token endpoint returned empty access token")
+	}
+
+	token := OAuth2Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		TokenType:    tokenResp.TokenType,
+		Scope:        tokenResp.Scope,
+	}
+
+	// Refresh token can be omitted if rotation is disabled
+	if token.RefreshToken == "" {
+		token.RefreshToken = current.RefreshToken
+	}
+
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	a.mu.Lock()
+	a.token = token
+	a.mu.Unlock()
+
+	if a.OnRefresh == nil {
+		return token, nil
+	}
+
+	err = a.OnRefresh(token)
+
+	if err != nil && a.OnError != nil {
+		a.OnError(fmt.Errorf("Can't persist token: %w", err))
+	}
+
+	return token, nil
+}
+
+// getTokenURL returns token endpoint URL
+func (a *AuthOAuth2) getTokenURL() string {
+	if a.TokenURL == "" {
+		return OAUTH2_TOKEN_URL
+	}
+
+	return a.TokenURL
+}
+
+// getClient returns HTTP client for token requests
+func (a *AuthOAuth2) getClient() *fasthttp.Client {
+	if a.Client == nil {
+		a.Client = &fasthttp.Client{
+			Name:         getUserAgent("", ""),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+		}
+	}
+
+	return a.Client
+}
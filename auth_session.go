@@ -0,0 +1,342 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _SESSION_COOKIE is name of Confluence session cookie
+const _SESSION_COOKIE = "JSESSIONID"
+
+// _LOGIN_PATH is path of login action
+const _LOGIN_PATH = "/dologin.action"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AuthSession is session cookie authorization. Session can be created by logging
+// in with username and password or provided as cookie. Expired sessions are
+// detected by redirects to the login page and renewed automatically if username
+// and password are set. AuthSession is safe for concurrent use.
+type AuthSession struct {
+	// URL is Confluence URL used for logging in
+	URL string
+
+	User     string
+	Password string
+
+	// Cookie is session cookie (JSESSIONID value or "name=value; name=value" string)
+	Cookie string
+
+	// Client is HTTP client used for logging in
+	Client *fasthttp.Client
+
+	jar        map[string]string
+	generation int
+	mu         sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrLoginFailed    = errors.New("Can't log in: invalid username or password")
+	ErrSessionExpired = errors.New("Session is expired and there are no credentials for logging in")
+	ErrEmptySession   = errors.New("Session cookie or username and password must be set")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewAuthSession creates new session authorization with given credentials
+func NewAuthSession(confluenceURL, user, password string) *AuthSession {
+	return &AuthSession{URL: confluenceURL, User: user, Password: password}
+}
+
+// NewAuthSessionWithCookie creates new session authorization with given session cookie
+func NewAuthSessionWithCookie(confluenceURL, cookie string) *AuthSession {
+	return &AuthSession{URL: confluenceURL, Cookie: cookie}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates authorization data
+func (a *AuthSession) Validate() error {
+	switch {
+	case a.URL == "":
+		return ErrEmptyURL
+	case a.Cookie != "":
+		return nil
+	case a.User == "" && a.Password == "":
+		return ErrEmptySession
+	case a.User == "":
+		return ErrEmptyUser
+	case a.Password == "":
+		return ErrEmptyPassword
+	}
+
+	return nil
+}
+
+// Encode returns empty string because session is passed using cookies
+func (a *AuthSession) Encode() string {
+	return ""
+}
+
+// Authorize adds session cookies to the request. If no cookies were supplied, it
+// will log in first. Supplied cookies are used as is (e.g. SSO cookies without
+// JSESSIONID), login is performed only if Confluence rejects them.
+func (a *AuthSession) Authorize(req *fasthttp.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.jar == nil {
+		a.jar = parseCookies(a.Cookie)
+
+		if len(a.jar) == 0 {
+			err := a.login()
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	a.setCookies(req)
+
+	return nil
+}
+
+// Login logs in to Confluence and creates new session
+func (a *AuthSession) Login() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.login()
+}
+
+// Session returns current session cookie value
+func (a *AuthSession) Session() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.jar[_SESSION_COOKIE]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// wrap wraps handler with session handling logic
+func (a *AuthSession) wrap(next Handler) Handler {
+	return func(call *Call) error {
+		a.mu.Lock()
+		generation := a.generation
+		a.mu.Unlock()
+
+		err := next(call)
+
+		if err != nil {
+			return err
+		}
+
+		if !isLoginRedirect(call.Response) {
+			a.storeCookies(call.Response)
+			return nil
+		}
+
+		err = a.renew(generation)
+
+		if err != nil {
+			return err
+		}
+
+		call.Response.Reset()
+		call.Request.Header.DelAllCookies()
+
+		a.mu.Lock()
+		a.setCookies(call.Request)
+		a.mu.Unlock()
+
+		err = next(call)
+
+		if err == nil {
+			a.storeCookies(call.Response)
+		}
+
+		return err
+	}
+}
+
+// renew creates new session if session with given generation is expired
+func (a *AuthSession) renew(generation int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Session already renewed by another request
+	if a.generation != generation {
+		return nil
+	}
+
+	if a.User == "" || a.Password == "" {
+		return ErrSessionExpired
+	}
+
+	return a.login()
+}
+
+// login logs in using username and password
+func (a *AuthSession) login() error {
+	if a.User == "" || a.Password == "" {
+		return ErrSessionExpired
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(strings.TrimRight(a.URL, "/") + _LOGIN_PATH)
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/x-www-form-urlencoded")
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	args := req.PostArgs()
+	args.Set("os_username", a.User)
+	args.Set("os_password", a.Password)
+	args.Set("os_cookie", "true")
+	args.Set("login", "Log in")
+
+	err := a.getClient().Do(req, resp)
+
+	if err != nil {
+		return fmt.Errorf("Can't send login request: %w", err)
+	}
+
+	reason := string(resp.Header.Peek("X-Seraph-LoginReason"))
+
+	if strings.Contains(reason, "FAILED") || strings.Contains(reason, "DENIED") {
+		return ErrLoginFailed
+	}
+
+	if resp.StatusCode() >= 400 {
+		return fmt.Errorf("Can't log in: %w", makeUnknownError(resp.StatusCode()))
+	}
+
+	jar := map[string]string{}
+
+	resp.Header.VisitAllCookie(func(key, value []byte) {
+		c := fasthttp.AcquireCookie()
+		defer fasthttp.ReleaseCookie(c)
+
+		if c.ParseBytes(value) == nil {
+			jar[string(c.Key())] = string(c.Value())
+		}
+	})
+
+	if jar[_SESSION_COOKIE] == "" {
+		return ErrLoginFailed
+	}
+
+	a.jar = jar
+	a.generation++
+
+	return nil
+}
+
+// setCookies adds all cookies from jar to the request
+func (a *AuthSession) setCookies(req *fasthttp.Request) {
+	for k, v := range a.jar {
+		req.Header.SetCookie(k, v)
+	}
+
+	if !req.Header.IsGet() {
+		req.Header.Set("X-Atlassian-Token", "no-check")
+	}
+}
+
+// storeCookies saves cookies from response to the jar
+func (a *AuthSession) storeCookies(resp *fasthttp.Response) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.jar == nil {
+		a.jar = map[string]string{}
+	}
+
+	resp.Header.VisitAllCookie(func(key, value []byte) {
+		c := fasthttp.AcquireCookie()
+		defer fasthttp.ReleaseCookie(c)
+
+		if c.ParseBytes(value) != nil {
+			return
+		}
+
+		if c.MaxAge() < 0 || (!c.Expire().IsZero() && c.Expire().Before(time.Now())) {
+			delete(a.jar, string(c.Key()))
+		} else {
+			a.jar[string(c.Key())] = string(c.Value())
+		}
+	})
+}
+
+// getClient returns HTTP client for login requests
+func (a *AuthSession) getClient() *fasthttp.Client {
+	if a.Client == nil {
+		a.Client = &fasthttp.Client{
+			Name:         getUserAgent("", ""),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+		}
+	}
+
+	return a.Client
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isLoginRedirect returns true if response is redirect to the login page
+func isLoginRedirect(resp *fasthttp.Response) bool {
+	switch resp.StatusCode() {
+	case 301, 302, 303, 307, 308:
+		return strings.Contains(string(resp.Header.Peek("Location")), "login.action")
+	case 401:
+		return true
+	}
+
+	return false
+}
+
+// parseCookies parses cookie string
+func parseCookies(cookie string) map[string]string {
+	result := map[string]string{}
+
+	if cookie == "" {
+		return result
+	}
+
+	if !strings.Contains(cookie, "=") {
+		result[_SESSION_COOKIE] = cookie
+		return result
+	}
+
+	for _, c := range strings.Split(cookie, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(c), "=")
+
+		if ok && name != "" {
+			result[name] = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,233 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _BULK_DEFAULT_CONCURRENCY is default max number of concurrent requests
+const _BULK_DEFAULT_CONCURRENCY = 8
+
+// _BULK_MAX_BATCH_SIZE is max number of content IDs in one CQL query
+const _BULK_MAX_BATCH_SIZE = 100
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// BulkParameters is params for fetching many items at once
+type BulkParameters struct {
+	// Expand is list of properties to expand
+	Expand []string
+
+	// Concurrency is max number of concurrent requests (8 is used if 0)
+	Concurrency int
+
+	// BatchSize is number of content IDs fetched with one CQL query (max 100). If
+	// 0, every content will be fetched with separate request.
+	BatchSize int
+}
+
+// BulkResult contains result of fetching one item
+type BulkResult[T any] struct {
+	Key   string // Content ID, space key or user identifier
+	Value T
+	Error error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrInvalidContentID is returned if content ID is not a number
+var ErrInvalidContentID = errors.New("Content ID must be a number")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetContentsByID fetches content with given IDs concurrently. Results are
+// returned in the same order as IDs. If BatchSize is set, content is fetched using
+// CQL queries "id in (…)", and content which wasn't found by query (e.g. trashed
+// content) is fetched separately.
+func (api *API) GetContentsByID(ids []string, params BulkParameters) []BulkResult[*Content] {
+	results := make([]BulkResult[*Content], len(ids))
+
+	for i, id := range ids {
+		results[i].Key = id
+	}
+
+	if params.BatchSize > 0 {
+		api.searchContentsByID(results, params)
+	}
+
+	forEachParallel(len(results), params.Concurrency, func(i int) {
+		if results[i].Value != nil || results[i].Error != nil {
+			return
+		}
+
+		results[i].Value, results[i].Error = api.GetContentByID(
+			results[i].Key, ContentIDParameters{Expand: params.Expand},
+		)
+	})
+
+	return results
+}
+
+// GetSpacesByKey fetches spaces with given keys concurrently. Results are returned
+// in the same order as keys.
+func (api *API) GetSpacesByKey(keys []string, params BulkParameters) []BulkResult[*Space] {
+	results := make([]BulkResult[*Space], len(keys))
+
+	forEachParallel(len(keys), params.Concurrency, func(i int) {
+		results[i].Key = keys[i]
+		results[i].Value, results[i].Error = api.GetSpace(
+			keys[i], ExpandParameters{Expand: params.Expand},
+		)
+	})
+
+	return results
+}
+
+// GetUsers fetches users concurrently. Results are returned in the same order as
+// given user parameters.
+func (api *API) GetUsers(users []UserParameters, params BulkParameters) []BulkResult[*User] {
+	results := make([]BulkResult[*User], len(users))
+
+	forEachParallel(len(users), params.Concurrency, func(i int) {
+		p := users[i]
+
+		if len(p.Expand) == 0 {
+			p.Expand = params.Expand
+		}
+
+		results[i].Key = getUserParamsKey(p)
+		results[i].Value, results[i].Error = api.GetUser(p)
+	})
+
+	return results
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// searchContentsByID fetches content using CQL queries
+func (api *API) searchContentsByID(results []BulkResult[*Content], params BulkParameters) {
+	var batches [][]int
+	var batch []int
+
+	batchSize := min(params.BatchSize, _BULK_MAX_BATCH_SIZE)
+
+	for i := range results {
+		if !isNumeric(results[i].Key) {
+			results[i].Error = ErrInvalidContentID
+			continue
+		}
+
+		batch = append(batch, i)
+
+		if len(batch) == batchSize {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+
+	if len(batch) != 0 {
+		batches = append(batches, batch)
+	}
+
+	forEachParallel(len(batches), params.Concurrency, func(n int) {
+		var ids []string
+
+		for _, i := range batches[n] {
+			ids = append(ids, results[i].Key)
+		}
+
+		content, err := api.SearchContent(ContentSearchParameters{
+			CQL:    "id in (" + strings.Join(ids, ",") + ")",
+			Expand: params.Expand,
+			Limit:  len(ids),
+		})
+
+		if err != nil {
+			for _, i := range batches[n] {
+				results[i].Error = err
+			}
+
+			return
+		}
+
+		found := map[string]*Content{}
+
+		for _, c := range content.Results {
+			found[c.ID] = c
+		}
+
+		for _, i := range batches[n] {
+			results[i].Value = found[results[i].Key]
+		}
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// forEachParallel calls given function for every index from 0 to n using limited
+// number of goroutines
+func forEachParallel(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = _BULK_DEFAULT_CONCURRENCY
+	}
+
+	var wg sync.WaitGroup
+
+	queue := make(chan int)
+
+	for range min(concurrency, n) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := range n {
+		queue <- i
+	}
+
+	close(queue)
+	wg.Wait()
+}
+
+// getUserParamsKey returns user identifier from parameters
+func getUserParamsKey(p UserParameters) string {
+	switch {
+	case p.AccountID != "":
+		return p.AccountID
+	case p.Key != "":
+		return p.Key
+	}
+
+	return p.Username
+}
+
+// isNumeric returns true if given string contains only digits
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
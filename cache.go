@@ -0,0 +1,388 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _CACHE_DEFAULT_SIZE is default size of LRU cache
+const _CACHE_DEFAULT_SIZE = 1000
+
+// _CACHE_DEFAULT_TTL is default TTL of cache entries
+const _CACHE_DEFAULT_TTL = time.Minute
+
+// Endpoint families
+const (
+	FAMILY_AUDIT    = "/rest/api/audit"
+	FAMILY_CONTENT  = "/rest/api/content"
+	FAMILY_GROUP    = "/rest/api/group"
+	FAMILY_SEARCH   = "/rest/api/search"
+	FAMILY_SPACE    = "/rest/api/space"
+	FAMILY_USER     = "/rest/api/user"
+	FAMILY_CALENDAR = "/rest/calendar-services"
+	FAMILY_V2       = "/api/v2"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Cache is interface for response cache backend
+type Cache interface {
+	// Get returns entry with given key
+	Get(key string) (*CacheEntry, bool)
+
+	// Set stores entry with given key
+	Set(key string, entry *CacheEntry)
+
+	// Delete removes entry with given key
+	Delete(key string)
+
+	// DeleteFunc removes all entries with keys matching given function
+	DeleteFunc(match func(key string) bool)
+}
+
+// CacheEntry contains cached response
+type CacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	Expires      time.Time
+}
+
+// CacheConfig contains cache configuration
+type CacheConfig struct {
+	// Backend is cache backend (LRU cache with 1000 entries is used if nil)
+	Backend Cache
+
+	// TTL is default TTL of cache entries (1 minute is used if 0)
+	TTL time.Duration
+
+	// TTLs contains TTLs for endpoint families (URI prefixes, e.g. FAMILY_CONTENT).
+	// Negative TTL disables caching for the family.
+	TTLs map[string]time.Duration
+}
+
+// LRUCache is in-memory cache with least recently used eviction policy.
+// LRUCache is safe for concurrent use.
+type LRUCache struct {
+	size  int
+	items map[string]*list.Element
+	order *list.List
+	mu    sync.Mutex
+}
+
+// lruItem is LRU cache item
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// responseCache is caching middleware
+type responseCache struct {
+	backend Cache
+	ttl     time.Duration
+	ttls    map[string]time.Duration
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// contentFamilies contains families which must be invalidated after content changes
+var contentFamilies = []string{FAMILY_CONTENT, FAMILY_SEARCH, FAMILY_SPACE, FAMILY_V2, "/json/"}
+
+// userFamilies contains families which must be invalidated after user or group
+// changes
+var userFamilies = []string{FAMILY_USER, FAMILY_GROUP}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewLRUCache creates new LRU cache with given max number of entries
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = _CACHE_DEFAULT_SIZE
+	}
+
+	return &LRUCache{
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// NewCacheMiddleware creates middleware which caches responses for GET requests.
+// Stale entries with ETag or Last-Modified info are revalidated using conditional
+// requests. Cached data is invalidated after any successful request which modifies
+// data.
+func NewCacheMiddleware(config CacheConfig) Middleware {
+	c := &responseCache{
+		backend: config.Backend,
+		ttl:     config.TTL,
+		ttls:    config.TTLs,
+	}
+
+	if c.backend == nil {
+		c.backend = NewLRUCache(_CACHE_DEFAULT_SIZE)
+	}
+
+	if c.ttl <= 0 {
+		c.ttl = _CACHE_DEFAULT_TTL
+	}
+
+	return c.middleware
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns entry with given key
+func (c *LRUCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return el.Value.(*lruItem).entry, true
+}
+
+// Set stores entry with given key
+func (c *LRUCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruItem{key, entry})
+
+	for c.order.Len() > c.size {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.items, el.Value.(*lruItem).key)
+	}
+}
+
+// Delete removes entry with given key
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+}
+
+// DeleteFunc removes all entries with keys matching given function
+func (c *LRUCache) DeleteFunc(match func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.items {
+		if match(key) {
+			c.order.Remove(el)
+			delete(c.items, key)
+		}
+	}
+}
+
+// Len returns number of entries in cache
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsExpired returns true if entry is expired
+func (e *CacheEntry) IsExpired() bool {
+	return time.Now().After(e.Expires)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// middleware is caching middleware
+func (c *responseCache) middleware(next Handler) Handler {
+	return func(call *Call) error {
+		if call.Method != "GET" {
+			err := next(call)
+
+			if err == nil && call.StatusCode() < 400 {
+				c.invalidate(call.URI)
+			}
+
+			return err
+		}
+
+		ttl := c.getTTL(call.URI)
+
+		// Binary data (e.g. attachments) can be arbitrarily large, so it is never
+		// cached
+		if ttl < 0 || call.isStream || strings.HasPrefix(call.URI, "/download/") {
+			return next(call)
+		}
+
+		key := getCacheKey(call)
+		entry, ok := c.backend.Get(key)
+
+		if ok && !entry.IsExpired() {
+			call.Response.SetStatusCode(200)
+			call.Response.SetBody(entry.Body)
+			return nil
+		}
+
+		if ok {
+			if entry.ETag != "" {
+				call.Request.Header.Set("If-None-Match", entry.ETag)
+			}
+
+			if entry.LastModified != "" {
+				call.Request.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+
+		err := next(call)
+
+		if err != nil {
+			return err
+		}
+
+		switch call.StatusCode() {
+		case 304:
+			if !ok {
+				return nil
+			}
+
+			c.backend.Set(key, &CacheEntry{
+				Body:         entry.Body,
+				ETag:         entry.ETag,
+				LastModified: entry.LastModified,
+				Expires:      time.Now().Add(ttl),
+			})
+
+			call.Response.SetStatusCode(200)
+			call.Response.SetBody(entry.Body)
+
+		case 200:
+			c.backend.Set(key, &CacheEntry{
+				Body:         append([]byte(nil), call.Response.Body()...),
+				ETag:         string(call.Response.Header.Peek("ETag")),
+				LastModified: string(call.Response.Header.Peek("Last-Modified")),
+				Expires:      time.Now().Add(ttl),
+			})
+		}
+
+		return nil
+	}
+}
+
+// invalidate removes cached data related to given URI
+func (c *responseCache) invalidate(uri string) {
+	families := []string{getEndpointFamily(uri)}
+
+	switch {
+	case isContentURI(uri):
+		families = contentFamilies
+	case isUserURI(uri):
+		families = userFamilies
+	}
+
+	c.backend.DeleteFunc(func(key string) bool {
+		_, keyURI, _ := strings.Cut(key, " ")
+
+		for _, family := range families {
+			if strings.HasPrefix(keyURI, family) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// getTTL returns TTL for given URI
+func (c *responseCache) getTTL(uri string) time.Duration {
+	var prefix string
+
+	ttl := c.ttl
+
+	for family, familyTTL := range c.ttls {
+		if strings.HasPrefix(uri, family) && len(family) > len(prefix) {
+			prefix, ttl = family, familyTTL
+		}
+	}
+
+	if ttl == 0 {
+		return c.ttl
+	}
+
+	return ttl
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCacheKey returns cache key for given call
+func getCacheKey(call *Call) string {
+	query := call.Params.ToQuery()
+
+	if query == "" {
+		return call.Method + " " + call.URI
+	}
+
+	return call.Method + " " + call.URI + "?" + query
+}
+
+// getEndpointFamily returns endpoint family for given URI
+func getEndpointFamily(uri string) string {
+	uri = getCallEndpoint(uri)
+	parts := strings.SplitN(strings.TrimPrefix(uri, "/"), "/", 4)
+
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+// isContentURI returns true if given URI is related to content
+func isContentURI(uri string) bool {
+	switch {
+	case strings.HasPrefix(uri, FAMILY_CONTENT),
+		strings.HasPrefix(uri, FAMILY_V2),
+		strings.HasPrefix(uri, FAMILY_SPACE) && strings.Contains(uri, "/content"):
+		return true
+	}
+
+	return false
+}
+
+// isUserURI returns true if given URI is related to users or groups
+func isUserURI(uri string) bool {
+	switch {
+	case strings.HasPrefix(uri, "/rest/api/admin/user"),
+		strings.HasPrefix(uri, "/rest/api/admin/group"),
+		strings.HasPrefix(uri, FAMILY_USER),
+		strings.HasPrefix(uri, FAMILY_GROUP):
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,269 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Circuit breaker states
+const (
+	CIRCUIT_CLOSED CircuitState = iota
+	CIRCUIT_OPEN
+	CIRCUIT_HALF_OPEN
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CircuitState is circuit breaker state
+type CircuitState uint8
+
+// CircuitBreakerConfig contains circuit breaker configuration
+type CircuitBreakerConfig struct {
+	// FailureThreshold is number of consecutive failures after which circuit will
+	// be opened (5 is used if 0)
+	FailureThreshold int
+
+	// SuccessThreshold is number of successful requests in half-open state after
+	// which circuit will be closed (1 is used if 0)
+	SuccessThreshold int
+
+	// HalfOpenRequests is max number of concurrent requests in half-open state
+	// (1 is used if 0)
+	HalfOpenRequests int
+
+	// OpenTimeout is duration of open state after which circuit will be switched
+	// to half-open state (30 seconds is used if 0)
+	OpenTimeout time.Duration
+
+	// IsFailure is function for checking if call is failed (by default transport
+	// errors and responses with 5xx status codes are treated as failures)
+	IsFailure func(call *Call, err error) bool
+
+	// OnStateChange is callback which is called after every state change
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker is circuit breaker which stops sending requests to Confluence
+// after series of failures. CircuitBreaker is safe for concurrent use.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+
+	state     CircuitState
+	failures  int
+	successes int
+	probes    int
+	openedAt  time.Time
+
+	// generation is incremented on every state change, so results of requests
+	// admitted in previous states are ignored
+	generation uint64
+
+	mu sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrCircuitOpen is returned if circuit is open and request wasn't sent
+var ErrCircuitOpen = errors.New("Circuit is open: Confluence is unavailable")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewCircuitBreaker creates new circuit breaker with given configuration
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+
+	if config.SuccessThreshold <= 0 {
+		config.SuccessThreshold = 1
+	}
+
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+
+	if config.IsFailure == nil {
+		config.IsFailure = isCallFailed
+	}
+
+	return &CircuitBreaker{config: config}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns name of state
+func (s CircuitState) String() string {
+	switch s {
+	case CIRCUIT_CLOSED:
+		return "closed"
+	case CIRCUIT_OPEN:
+		return "open"
+	case CIRCUIT_HALF_OPEN:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Middleware wraps handler with circuit breaker logic
+func (cb *CircuitBreaker) Middleware(next Handler) Handler {
+	return func(call *Call) error {
+		generation, err := cb.allow()
+
+		if err != nil {
+			return err
+		}
+
+		err = next(call)
+
+		cb.report(generation, cb.config.IsFailure(call, err))
+
+		return err
+	}
+}
+
+// State returns current state of circuit
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CIRCUIT_OPEN && time.Since(cb.openedAt) >= cb.config.OpenTimeout {
+		return CIRCUIT_HALF_OPEN
+	}
+
+	return cb.state
+}
+
+// Reset closes circuit and resets all counters
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	from := cb.setState(CIRCUIT_CLOSED)
+	cb.mu.Unlock()
+
+	cb.notify(from, CIRCUIT_CLOSED)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// allow checks if request can be sent and returns generation of state in which
+// request was admitted
+func (cb *CircuitBreaker) allow() (uint64, error) {
+	cb.mu.Lock()
+
+	from := cb.state
+
+	if cb.state == CIRCUIT_OPEN && time.Since(cb.openedAt) >= cb.config.OpenTimeout {
+		cb.setState(CIRCUIT_HALF_OPEN)
+	}
+
+	to, generation := cb.state, cb.generation
+
+	var err error
+
+	switch {
+	case cb.state == CIRCUIT_OPEN:
+		err = ErrCircuitOpen
+	case cb.state == CIRCUIT_HALF_OPEN && cb.probes >= cb.config.HalfOpenRequests:
+		err = ErrCircuitOpen
+	case cb.state == CIRCUIT_HALF_OPEN:
+		cb.probes++
+	}
+
+	cb.mu.Unlock()
+
+	cb.notify(from, to)
+
+	return generation, err
+}
+
+// report updates circuit state using result of request admitted in state with
+// given generation
+func (cb *CircuitBreaker) report(generation uint64, failed bool) {
+	cb.mu.Lock()
+
+	if generation != cb.generation {
+		cb.mu.Unlock()
+		return
+	}
+
+	from := cb.state
+
+	switch cb.state {
+	case CIRCUIT_CLOSED:
+		if !failed {
+			cb.failures = 0
+			break
+		}
+
+		cb.failures++
+
+		if cb.failures >= cb.config.FailureThreshold {
+			cb.setState(CIRCUIT_OPEN)
+		}
+
+	case CIRCUIT_HALF_OPEN:
+		cb.probes = max(cb.probes-1, 0)
+
+		if failed {
+			cb.setState(CIRCUIT_OPEN)
+			break
+		}
+
+		cb.successes++
+
+		if cb.successes >= cb.config.SuccessThreshold {
+			cb.setState(CIRCUIT_CLOSED)
+		}
+	}
+
+	to := cb.state
+
+	cb.mu.Unlock()
+
+	cb.notify(from, to)
+}
+
+// setState changes state and resets counters
+func (cb *CircuitBreaker) setState(state CircuitState) CircuitState {
+	from := cb.state
+
+	cb.state = state
+	cb.failures, cb.successes, cb.probes = 0, 0, 0
+	cb.generation++
+
+	if state == CIRCUIT_OPEN {
+		cb.openedAt = time.Now()
+	}
+
+	return from
+}
+
+// notify calls state change callback if state was changed
+func (cb *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(from, to)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isCallFailed returns true if call failed with transport error or server error
+func isCallFailed(call *Call, err error) bool {
+	return err != nil || call.StatusCode() >= 500
+}
@@ -0,0 +1,74 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// flightGroup is group of in-flight requests
+type flightGroup struct {
+	calls map[string]*flightCall
+	mu    sync.Mutex
+}
+
+// flightCall is in-flight request
+type flightCall struct {
+	wg         sync.WaitGroup
+	data       []byte
+	statusCode int
+	err        error
+}
+
+// rawResult is result which receives raw response body instead of decoded data
+type rawResult struct {
+	data []byte
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NoCoalescing returns copy of API instance which sends every request separately
+// even if coalescing is enabled
+func (api *API) NoCoalescing() *API {
+	clone := *api
+	clone.flights = nil
+	return &clone
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// do executes given function only once for all concurrent calls with the same key
+// and returns raw response body to every caller. Body is shared between callers
+// and must not be modified, every caller decodes it separately.
+func (g *flightGroup) do(key string, fn func() (int, []byte, error)) (int, []byte, error) {
+	g.mu.Lock()
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+
+		return c.statusCode, c.data, c.err
+	}
+
+	c := &flightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.statusCode, c.data, c.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	c.wg.Done()
+
+	return c.statusCode, c.data, c.err
+}
@@ -8,11 +8,17 @@ package confluence
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/url"
 	"runtime"
 	"strconv"
 	"strings"
@@ -26,13 +32,42 @@ import (
 // API is Confluence API struct
 type API struct {
 	Client *fasthttp.Client // Client is client for http requests
+	Logger *slog.Logger     // Logger is optional logger for requests
 
-	url  string // Confluence URL
-	auth string // Auth data
+	url     string // Confluence URL
+	auth    Auth   // Auth data
+	isCloud bool   // Confluence Cloud mode
+
+	isClientChanged bool // HTTP client was modified by options
+
+	headers     map[string]string // Default headers
+	middlewares []Middleware      // Request/response middlewares
+	flights     *flightGroup      // In-flight requests for coalescing
+	ctx         context.Context   // Context passed to middlewares
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// _CLOUD_BASE_PATH is base path of Confluence Cloud instances
+const _CLOUD_BASE_PATH = "/wiki"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rawBody is request body which is sent as is
+type rawBody struct {
+	ContentType string
+	Data        []byte
+}
+
+// archiveRequest is request body for archiving pages
+type archiveRequest struct {
+	Pages []archivePage `json:"pages"`
+}
+
+type archivePage struct {
+	ID json.Number `json:"id"`
+}
+
 type restrictionsInfo struct {
 	Permissions []permission                    `json:"permissions"`
 	Users       map[string]*restrictionUserInfo `json:"users"`
@@ -55,6 +90,24 @@ var (
 	ErrNoSpace     = errors.New("There is no space with the given key, or if the calling user does not have permission to view the space")
 	ErrNoUserPerms = errors.New("User does not have permission to view users")
 	ErrNoUserFound = errors.New("User with the given username or userkey does not exist")
+	ErrNoAccountID = errors.New("AccountID is mandatory for Confluence Cloud and must be set")
+	ErrCloudOnly   = errors.New("Method is supported only by Confluence Cloud")
+
+	ErrNoDownloadLink = errors.New("Attachment has no download link")
+
+	ErrBadRequest      = errors.New("Request data is invalid")
+	ErrNoProperty      = errors.New("There is no content property with the given key")
+	ErrNilProperty     = errors.New("Content property can't be nil")
+	ErrVersionConflict = errors.New("Version number is not equal to the current version number incremented by one")
+
+	ErrNoAdminPerms  = errors.New("User does not have administrator permission to manage users and groups")
+	ErrNoGroup       = errors.New("There is no group with the given name")
+	ErrGroupExists   = errors.New("Group with the given name already exists")
+	ErrNoUserOrGroup = errors.New("There is no user or group with the given name")
+
+	ErrNoWebhook     = errors.New("There is no webhook with the given id")
+	ErrNilWebhook    = errors.New("Webhook can't be nil")
+	ErrWebhookExists = errors.New("Webhook with the given name already exists")
 )
 
 var emptyParams = EmptyParameters{}
@@ -62,18 +115,20 @@ var emptyParams = EmptyParameters{}
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // NewAPI create new API struct
-func NewAPI(url string, auth Auth) (*API, error) {
-	if url == "" {
-		return nil, ErrEmptyURL
+func NewAPI(url string, auth Auth, options ...Option) (*API, error) {
+	url, err := normalizeURL(url)
+
+	if err != nil {
+		return nil, err
 	}
 
-	err := auth.Validate()
+	err = auth.Validate()
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &API{
+	api := &API{
 		Client: &fasthttp.Client{
 			Name:                getUserAgent("", ""),
 			MaxIdleConnDuration: 5 * time.Second,
@@ -83,8 +138,45 @@ func NewAPI(url string, auth Auth) (*API, error) {
 		},
 
 		url:  url,
-		auth: auth.Encode(),
-	}, nil
+		auth: auth,
+	}
+
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+
+		err = option(api)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if api.isCloud && !strings.HasSuffix(api.url, _CLOUD_BASE_PATH) {
+		api.url += _CLOUD_BASE_PATH
+	}
+
+	return api, nil
+}
+
+// NewCloudAPI create new API struct for Confluence Cloud
+func NewCloudAPI(url string, auth Auth, options ...Option) (*API, error) {
+	return NewAPI(url, auth, append(options, WithCloud())...)
+}
+
+// WithContext returns copy of API which passes given context to middlewares with
+// every call (e.g. for joining client spans to the caller's trace). Copy shares
+// HTTP client, authorization and middlewares with the original API.
+func (api *API) WithContext(ctx context.Context) *API {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	apiCopy := *api
+	apiCopy.ctx = ctx
+
+	return &apiCopy
 }
 
 // SetUserAgent set user-agent string based on app name and version
@@ -99,7 +191,7 @@ func (api *API) SetUserAgent(app, version string) {
 func (api *API) GetAuditRecords(params AuditParameters) (*AuditRecordCollection, error) {
 	result := &AuditRecordCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/audit",
+		"GetAuditRecords", "GET", "/rest/api/audit",
 		params, result, nil,
 	)
 
@@ -122,7 +214,7 @@ func (api *API) GetAuditRecords(params AuditParameters) (*AuditRecordCollection,
 func (api *API) GetAuditRecordsSince(params AuditSinceParameters) (*AuditRecordCollection, error) {
 	result := &AuditRecordCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/audit/since",
+		"GetAuditRecordsSince", "GET", "/rest/api/audit/since",
 		params, result, nil,
 	)
 
@@ -145,7 +237,7 @@ func (api *API) GetAuditRecordsSince(params AuditSinceParameters) (*AuditRecordC
 func (api *API) GetAuditRetention() (*AuditRetentionInfo, error) {
 	result := &AuditRetentionInfo{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/audit/retention",
+		"GetAuditRetention", "GET", "/rest/api/audit/retention",
 		emptyParams, result, nil,
 	)
 
@@ -168,7 +260,7 @@ func (api *API) GetAuditRetention() (*AuditRetentionInfo, error) {
 func (api *API) GetContent(params ContentParameters) (*ContentCollection, error) {
 	result := &ContentCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content",
+		"GetContent", "GET", "/rest/api/content",
 		params, result, nil,
 	)
 
@@ -193,7 +285,7 @@ func (api *API) GetContent(params ContentParameters) (*ContentCollection, error)
 func (api *API) GetContentByID(contentID string, params ContentIDParameters) (*Content, error) {
 	result := &Content{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID,
+		"GetContentByID", "GET", "/rest/api/content/"+contentID,
 		params, result, nil,
 	)
 
@@ -213,13 +305,13 @@ func (api *API) GetContentByID(contentID string, params ContentIDParameters) (*C
 	}
 }
 
-// GetContentHistory fetch the history of a particular piece of content
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content-getHistory
-func (api *API) GetContentHistory(contentID string, params ExpandParameters) (*History, error) {
-	result := &History{}
+// CreateContent creates new page, blog post or comment
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content-createContent
+func (api *API) CreateContent(content *ContentInput) (*Content, error) {
+	result := &Content{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/history",
-		params, result, nil,
+		"CreateContent", "POST", "/rest/api/content",
+		emptyParams, result, content,
 	)
 
 	if err != nil {
@@ -227,24 +319,27 @@ func (api *API) GetContentHistory(contentID string, params ExpandParameters) (*H
 	}
 
 	switch statusCode {
-	case 200:
+	case 200, 201:
 		return result, nil
+	case 400:
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
 	case 404:
-		return nil, ErrNoContent
+		return nil, ErrNoSpace
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetContentChildren fetch a map of the direct children of a piece of Content
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child-children
-func (api *API) GetContentChildren(contentID string, params ChildrenParameters) (*Contents, error) {
-	result := &Contents{}
+// UpdateContent updates content with given ID. Version number must be set to the
+// current version number incremented by one.
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content-update
+func (api *API) UpdateContent(contentID string, content *ContentInput) (*Content, error) {
+	result := &Content{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/child",
-		params, result, nil,
+		"UpdateContent", "PUT", "/rest/api/content/"+contentID,
+		emptyParams, result, content,
 	)
 
 	if err != nil {
@@ -254,46 +349,65 @@ func (api *API) GetContentChildren(contentID string, params ChildrenParameters)
 	switch statusCode {
 	case 200:
 		return result, nil
+	case 400:
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
 	case 404:
 		return nil, ErrNoContent
+	case 409:
+		return nil, ErrVersionConflict
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetContentChildrenByType the direct children of a piece of Content, limited to a single child type
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child-childrenOfType
-func (api *API) GetContentChildrenByType(contentID, contentType string, params ChildrenParameters) (*ContentCollection, error) {
-	result := &ContentCollection{}
+// ArchivePages archives pages with given IDs. Archiving is performed by
+// Confluence asynchronously. Archiving API is available only in Confluence Cloud.
+func (api *API) ArchivePages(pageIDs []string) error {
+	if !api.isCloud {
+		return ErrCloudOnly
+	}
+
+	pages := &archiveRequest{}
+
+	for _, id := range pageIDs {
+		if !isNumeric(id) {
+			return ErrInvalidContentID
+		}
+
+		pages.Pages = append(pages.Pages, archivePage{ID: json.Number(id)})
+	}
+
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/child/"+contentType,
-		params, result, nil,
+		"ArchivePages", "POST", "/rest/api/content/archive",
+		emptyParams, nil, pages,
 	)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	switch statusCode {
-	case 200:
-		return result, nil
+	case 200, 202:
+		return nil
+	case 400:
+		return ErrBadRequest
 	case 403:
-		return nil, ErrNoPerms
+		return ErrNoPerms
 	case 404:
-		return nil, ErrNoContent
+		return ErrNoContent
 	default:
-		return nil, makeUnknownError(statusCode)
+		return makeUnknownError(statusCode)
 	}
 }
 
-// GetContentComments fetch the comments of a content
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child-commentsOfContent
-func (api *API) GetContentComments(contentID string, params ChildrenParameters) (*ContentCollection, error) {
-	result := &ContentCollection{}
+// GetContentHistory fetch the history of a particular piece of content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content-getHistory
+func (api *API) GetContentHistory(contentID string, params ExpandParameters) (*History, error) {
+	result := &History{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/child/comment",
+		"GetContentHistory", "GET", "/rest/api/content/"+contentID+"/history",
 		params, result, nil,
 	)
 
@@ -313,12 +427,12 @@ func (api *API) GetContentComments(contentID string, params ChildrenParameters)
 	}
 }
 
-// GetAttachments fetch list of attachment Content entities within a single container
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child/attachment-getAttachments
-func (api *API) GetAttachments(contentID string, params AttachmentParameters) (*ContentCollection, error) {
-	result := &ContentCollection{}
+// GetContentChildren fetch a map of the direct children of a piece of Content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child-children
+func (api *API) GetContentChildren(contentID string, params ChildrenParameters) (*Contents, error) {
+	result := &Contents{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/child/attachment",
+		"GetContentChildren", "GET", "/rest/api/content/"+contentID+"/child",
 		params, result, nil,
 	)
 
@@ -338,12 +452,12 @@ func (api *API) GetAttachments(contentID string, params AttachmentParameters) (*
 	}
 }
 
-// GetDescendants fetch a map of the descendants of a piece of Content
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/descendant-descendants
-func (api *API) GetDescendants(contentID string, params ExpandParameters) (*Contents, error) {
-	result := &Contents{}
+// GetContentChildrenByType the direct children of a piece of Content, limited to a single child type
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child-childrenOfType
+func (api *API) GetContentChildrenByType(contentID, contentType string, params ChildrenParameters) (*ContentCollection, error) {
+	result := &ContentCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/descendant",
+		"GetContentChildrenByType", "GET", "/rest/api/content/"+contentID+"/child/"+contentType,
 		params, result, nil,
 	)
 
@@ -363,12 +477,12 @@ func (api *API) GetDescendants(contentID string, params ExpandParameters) (*Cont
 	}
 }
 
-// GetDescendantsOfType fetch the direct descendants of a piece of Content, limited to a single descendant type
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/descendant-descendantsOfType
-func (api *API) GetDescendantsOfType(contentID, descType string, params ExpandParameters) (*ContentCollection, error) {
+// GetContentComments fetch the comments of a content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child-commentsOfContent
+func (api *API) GetContentComments(contentID string, params ChildrenParameters) (*ContentCollection, error) {
 	result := &ContentCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/descendant/"+descType,
+		"GetContentComments", "GET", "/rest/api/content/"+contentID+"/child/comment",
 		params, result, nil,
 	)
 
@@ -388,12 +502,12 @@ func (api *API) GetDescendantsOfType(contentID, descType string, params ExpandPa
 	}
 }
 
-// GetLabels fetch the list of labels on a piece of Content
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/label-labels
-func (api *API) GetLabels(contentID string, params LabelParameters) (*LabelCollection, error) {
-	result := &LabelCollection{}
+// GetAttachments fetch list of attachment Content entities within a single container
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child/attachment-getAttachments
+func (api *API) GetAttachments(contentID string, params AttachmentParameters) (*ContentCollection, error) {
+	result := &ContentCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/label",
+		"GetAttachments", "GET", "/rest/api/content/"+contentID+"/child/attachment",
 		params, result, nil,
 	)
 
@@ -413,40 +527,46 @@ func (api *API) GetLabels(contentID string, params LabelParameters) (*LabelColle
 	}
 }
 
-// GetRestrictions returns restrictions for the content with permissions inheritance.
-// Confluence API doesn't provide such an API method, so we use private JSON API.
-func (api *API) GetRestrictions(contentID, parentPageId, spaceKey string) (*Restrictions, error) {
-	url := "/pages/getcontentpermissions.action"
-	url += "?contentId=" + contentID
-	url += "&parentPageId=" + parentPageId
-	url += "&spaceKey=" + spaceKey
+// DownloadAttachment downloads attachment data and writes it to given writer
+func (api *API) DownloadAttachment(attachment *Content, w io.Writer) error {
+	if attachment == nil || attachment.Links == nil || attachment.Links.Download == "" {
+		return ErrNoDownloadLink
+	}
 
-	result := &restrictionsInfo{}
-	statusCode, err := api.doRequest("GET", url, emptyParams, result, nil)
+	statusCode, err := api.doRequest(
+		"DownloadAttachment", "GET", attachment.Links.Download,
+		emptyParams, w, nil,
+	)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	switch statusCode {
 	case 200:
-		return convertRestrictionsData(result), nil
+		return nil
 	case 403:
-		return nil, ErrNoPerms
+		return ErrNoPerms
 	case 404:
-		return nil, ErrNoContent
+		return ErrNoContent
 	default:
-		return nil, makeUnknownError(statusCode)
+		return makeUnknownError(statusCode)
 	}
 }
 
-// GetRestrictionsByOperation fetch info about all restrictions by operation
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/restriction-byOperation
-func (api *API) GetRestrictionsByOperation(contentID string, params ExpandParameters) (*Restrictions, error) {
-	result := &Restrictions{}
+// CreateAttachment uploads new attachment to the content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child/attachment-createAttachments
+func (api *API) CreateAttachment(contentID string, attachment *AttachmentInput) (*Content, error) {
+	body, err := encodeAttachment(attachment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ContentCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/restriction/byOperation",
-		params, result, nil,
+		"CreateAttachment", "POST", "/rest/api/content/"+contentID+"/child/attachment",
+		emptyParams, result, body,
 	)
 
 	if err != nil {
@@ -455,21 +575,35 @@ func (api *API) GetRestrictionsByOperation(contentID string, params ExpandParame
 
 	switch statusCode {
 	case 200:
-		return result, nil
+		if len(result.Results) == 0 {
+			return nil, makeUnknownError(statusCode)
+		}
+
+		return result.Results[0], nil
+	case 400:
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetRestrictionsForOperation fetch info about all restrictions of given operation
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/restriction-forOperation
-func (api *API) GetRestrictionsForOperation(contentID, operation string, params CollectionParameters) (*Restriction, error) {
-	result := &Restriction{}
+// UpdateAttachmentData uploads new version of attachment data
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/child/attachment-updateData
+func (api *API) UpdateAttachmentData(contentID, attachmentID string, attachment *AttachmentInput) (*Content, error) {
+	body, err := encodeAttachment(attachment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Content{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/"+contentID+"/restriction/byOperation/"+operation,
-		params, result, nil,
+		"UpdateAttachmentData", "POST", "/rest/api/content/"+contentID+"/child/attachment/"+attachmentID+"/data",
+		emptyParams, result, body,
 	)
 
 	if err != nil {
@@ -479,19 +613,23 @@ func (api *API) GetRestrictionsForOperation(contentID, operation string, params
 	switch statusCode {
 	case 200:
 		return result, nil
+	case 400:
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetGroups fetch collection of user groups
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#group-getGroups
-func (api *API) GetGroups(params CollectionParameters) (*GroupCollection, error) {
-	result := &GroupCollection{}
+// GetDescendants fetch a map of the descendants of a piece of Content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/descendant-descendants
+func (api *API) GetDescendants(contentID string, params ExpandParameters) (*Contents, error) {
+	result := &Contents{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/group",
+		"GetDescendants", "GET", "/rest/api/content/"+contentID+"/descendant",
 		params, result, nil,
 	)
 
@@ -504,17 +642,19 @@ func (api *API) GetGroups(params CollectionParameters) (*GroupCollection, error)
 		return result, nil
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetGroup fetch the user group with the group name
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#group-getGroup
-func (api *API) GetGroup(groupName string, params ExpandParameters) (*Group, error) {
-	result := &Group{}
+// GetDescendantsOfType fetch the direct descendants of a piece of Content, limited to a single descendant type
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/descendant-descendantsOfType
+func (api *API) GetDescendantsOfType(contentID, descType string, params ExpandParameters) (*ContentCollection, error) {
+	result := &ContentCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/group/"+groupName,
+		"GetDescendantsOfType", "GET", "/rest/api/content/"+contentID+"/descendant/"+descType,
 		params, result, nil,
 	)
 
@@ -527,17 +667,19 @@ func (api *API) GetGroup(groupName string, params ExpandParameters) (*Group, err
 		return result, nil
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetGroupMembers fetch a collection of users in the given group
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#group-getMembers
-func (api *API) GetGroupMembers(groupName string, params CollectionParameters) (*UserCollection, error) {
-	result := &UserCollection{}
+// GetLabels fetch the list of labels on a piece of Content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/label-labels
+func (api *API) GetLabels(contentID string, params LabelParameters) (*LabelCollection, error) {
+	result := &LabelCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/group/"+groupName+"/member",
+		"GetLabels", "GET", "/rest/api/content/"+contentID+"/label",
 		params, result, nil,
 	)
 
@@ -550,18 +692,26 @@ func (api *API) GetGroupMembers(groupName string, params CollectionParameters) (
 		return result, nil
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// Search search for entities in Confluence using the Confluence Query Language (CQL)
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#search-search
-func (api *API) Search(params SearchParameters) (*SearchResult, error) {
-	result := &SearchResult{}
+// AddLabels adds labels to the content
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/label-addLabels
+func (api *API) AddLabels(contentID string, labels []string) (*LabelCollection, error) {
+	var data []*Label
+
+	for _, label := range labels {
+		data = append(data, &Label{Prefix: "global", Name: label})
+	}
+
+	result := &LabelCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/search",
-		params, result, nil,
+		"AddLabels", "POST", "/rest/api/content/"+contentID+"/label",
+		emptyParams, result, data,
 	)
 
 	if err != nil {
@@ -572,20 +722,22 @@ func (api *API) Search(params SearchParameters) (*SearchResult, error) {
 	case 200:
 		return result, nil
 	case 400:
-		return nil, ErrQueryError
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// SearchContent fetch a list of content using the Confluence Query Language (CQL)
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content-search
-func (api *API) SearchContent(params ContentSearchParameters) (*ContentCollection, error) {
-	result := &ContentCollection{}
+// GetContentProperties fetch the list of content properties
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/property-findAll
+func (api *API) GetContentProperties(contentID string, params CollectionParameters) (*ContentPropertyCollection, error) {
+	result := &ContentPropertyCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/content/search",
+		"GetContentProperties", "GET", "/rest/api/content/"+contentID+"/property",
 		params, result, nil,
 	)
 
@@ -596,21 +748,21 @@ func (api *API) SearchContent(params ContentSearchParameters) (*ContentCollectio
 	switch statusCode {
 	case 200:
 		return result, nil
-	case 400:
-		return nil, ErrQueryError
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetSpaces fetch information about a number of spaces
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-spaces
-func (api *API) GetSpaces(params SpaceParameters) (*SpaceCollection, error) {
-	result := &SpaceCollection{}
+// GetContentProperty fetch content property with given key
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/property-findByKey
+func (api *API) GetContentProperty(contentID, key string, params ExpandParameters) (*ContentProperty, error) {
+	result := &ContentProperty{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/space",
+		"GetContentProperty", "GET", "/rest/api/content/"+contentID+"/property/"+url.PathEscape(key),
 		params, result, nil,
 	)
 
@@ -623,18 +775,24 @@ func (api *API) GetSpaces(params SpaceParameters) (*SpaceCollection, error) {
 		return result, nil
 	case 403:
 		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoProperty
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetSpace fetch information about a space
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-space
-func (api *API) GetSpace(spaceKey string, params Parameters) (*Space, error) {
-	result := &Space{}
+// CreateContentProperty creates new content property
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/property-create
+func (api *API) CreateContentProperty(contentID string, property *ContentProperty) (*ContentProperty, error) {
+	if property == nil {
+		return nil, ErrNilProperty
+	}
+
+	result := &ContentProperty{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/space/"+spaceKey,
-		params, result, nil,
+		"CreateContentProperty", "POST", "/rest/api/content/"+contentID+"/property",
+		emptyParams, result, property,
 	)
 
 	if err != nil {
@@ -642,24 +800,33 @@ func (api *API) GetSpace(spaceKey string, params Parameters) (*Space, error) {
 	}
 
 	switch statusCode {
-	case 200:
+	case 200, 201:
 		return result, nil
+	case 400:
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
 	case 404:
-		return nil, ErrNoSpace
+		return nil, ErrNoContent
+	case 409:
+		return nil, ErrVersionConflict
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetSpaceContent fetch the content in this given space
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-contents
-func (api *API) GetSpaceContent(spaceKey string, params SpaceParameters) (*Contents, error) {
-	result := &Contents{}
+// UpdateContentProperty updates content property. Version number must be set to
+// the current version number incremented by one.
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/property-update
+func (api *API) UpdateContentProperty(contentID string, property *ContentProperty) (*ContentProperty, error) {
+	if property == nil {
+		return nil, ErrNilProperty
+	}
+
+	result := &ContentProperty{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/space/"+spaceKey+"/content",
-		params, result, nil,
+		"UpdateContentProperty", "PUT", "/rest/api/content/"+contentID+"/property/"+url.PathEscape(property.Key),
+		emptyParams, result, property,
 	)
 
 	if err != nil {
@@ -669,23 +836,29 @@ func (api *API) GetSpaceContent(spaceKey string, params SpaceParameters) (*Conte
 	switch statusCode {
 	case 200:
 		return result, nil
+	case 400:
+		return nil, ErrBadRequest
 	case 403:
 		return nil, ErrNoPerms
 	case 404:
-		return nil, ErrNoContent
+		return nil, ErrNoProperty
+	case 409:
+		return nil, ErrVersionConflict
 	default:
 		return nil, makeUnknownError(statusCode)
 	}
 }
 
-// GetSpaceContentWithType fetch the content in this given space with the given type
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-contentsWithType
-func (api *API) GetSpaceContentWithType(spaceKey, contentType string, params SpaceParameters) (*Contents, error) {
-	result := &Contents{}
-	statusCode, err := api.doRequest(
-		"GET", "/rest/api/space/"+spaceKey+"/content/"+contentType,
-		params, result, nil,
-	)
+// GetRestrictions returns restrictions for the content with permissions inheritance.
+// Confluence API doesn't provide such an API method, so we use private JSON API.
+func (api *API) GetRestrictions(contentID, parentPageId, spaceKey string) (*Restrictions, error) {
+	url := "/pages/getcontentpermissions.action"
+	url += "?contentId=" + contentID
+	url += "&parentPageId=" + parentPageId
+	url += "&spaceKey=" + spaceKey
+
+	result := &restrictionsInfo{}
+	statusCode, err := api.doRequest("GetRestrictions", "GET", url, emptyParams, result, nil)
 
 	if err != nil {
 		return nil, err
@@ -693,7 +866,7 @@ func (api *API) GetSpaceContentWithType(spaceKey, contentType string, params Spa
 
 	switch statusCode {
 	case 200:
-		return result, nil
+		return convertRestrictionsData(result), nil
 	case 403:
 		return nil, ErrNoPerms
 	case 404:
@@ -703,12 +876,378 @@ func (api *API) GetSpaceContentWithType(spaceKey, contentType string, params Spa
 	}
 }
 
-// GetUser fetch information about a user identified by either user key or username
-// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#user-getUser
-func (api *API) GetUser(params UserParameters) (*User, error) {
+// GetRestrictionsByOperation fetch info about all restrictions by operation
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/restriction-byOperation
+func (api *API) GetRestrictionsByOperation(contentID string, params ExpandParameters) (*Restrictions, error) {
+	result := &Restrictions{}
+	statusCode, err := api.doRequest(
+		"GetRestrictionsByOperation", "GET", "/rest/api/content/"+contentID+"/restriction/byOperation",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetRestrictionsForOperation fetch info about all restrictions of given operation
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content/{id}/restriction-forOperation
+func (api *API) GetRestrictionsForOperation(contentID, operation string, params CollectionParameters) (*Restriction, error) {
+	result := &Restriction{}
+	statusCode, err := api.doRequest(
+		"GetRestrictionsForOperation", "GET", "/rest/api/content/"+contentID+"/restriction/byOperation/"+operation,
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetGroups fetch collection of user groups
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#group-getGroups
+func (api *API) GetGroups(params CollectionParameters) (*GroupCollection, error) {
+	result := &GroupCollection{}
+	statusCode, err := api.doRequest(
+		"GetGroups", "GET", "/rest/api/group",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetGroup fetch the user group with the group name
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#group-getGroup
+func (api *API) GetGroup(groupName string, params ExpandParameters) (*Group, error) {
+	result := &Group{}
+	statusCode, err := api.doRequest(
+		"GetGroup", "GET", "/rest/api/group/"+groupName,
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetGroupMembers fetch a collection of users in the given group
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#group-getMembers
+func (api *API) GetGroupMembers(groupName string, params CollectionParameters) (*UserCollection, error) {
+	result := &UserCollection{}
+	statusCode, err := api.doRequest(
+		"GetGroupMembers", "GET", "/rest/api/group/"+groupName+"/member",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// CreateGroup creates new user group with given name
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/admin/group-createGroup
+func (api *API) CreateGroup(groupName string) (*Group, error) {
+	result := &Group{}
+	statusCode, err := api.doRequest(
+		"CreateGroup", "POST", "/rest/api/admin/group",
+		emptyParams, result, &Group{Type: "group", Name: groupName},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200, 201:
+		return result, nil
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrNoAdminPerms
+	case 409:
+		return nil, ErrGroupExists
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// DeleteGroup deletes user group with given name
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/admin/group-deleteGroup
+func (api *API) DeleteGroup(groupName string) error {
+	statusCode, err := api.doRequest(
+		"DeleteGroup", "DELETE", "/rest/api/admin/group/"+url.PathEscape(groupName),
+		emptyParams, nil, nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 403:
+		return ErrNoAdminPerms
+	case 404:
+		return ErrNoGroup
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// AddGroupMember adds user with given username to the group
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/user/group-addUserToGroup
+func (api *API) AddGroupMember(groupName, username string) error {
+	statusCode, err := api.doRequest(
+		"AddGroupMember", "PUT", "/rest/api/user/"+url.PathEscape(username)+"/group/"+url.PathEscape(groupName),
+		emptyParams, nil, nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 403:
+		return ErrNoAdminPerms
+	case 404:
+		return ErrNoUserOrGroup
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// RemoveGroupMember removes user with given username from the group
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/user/group-removeUserFromGroup
+func (api *API) RemoveGroupMember(groupName, username string) error {
+	statusCode, err := api.doRequest(
+		"RemoveGroupMember", "DELETE", "/rest/api/user/"+url.PathEscape(username)+"/group/"+url.PathEscape(groupName),
+		emptyParams, nil, nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 403:
+		return ErrNoAdminPerms
+	case 404:
+		return ErrNoUserOrGroup
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// Search search for entities in Confluence using the Confluence Query Language (CQL)
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#search-search
+func (api *API) Search(params SearchParameters) (*SearchResult, error) {
+	result := &SearchResult{}
+	statusCode, err := api.doRequest(
+		"Search", "GET", "/rest/api/search",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrQueryError
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// SearchContent fetch a list of content using the Confluence Query Language (CQL)
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#content-search
+func (api *API) SearchContent(params ContentSearchParameters) (*ContentCollection, error) {
+	result := &ContentCollection{}
+	statusCode, err := api.doRequest(
+		"SearchContent", "GET", "/rest/api/content/search",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrQueryError
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetSpaces fetch information about a number of spaces
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-spaces
+func (api *API) GetSpaces(params SpaceParameters) (*SpaceCollection, error) {
+	result := &SpaceCollection{}
+	statusCode, err := api.doRequest(
+		"GetSpaces", "GET", "/rest/api/space",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetSpace fetch information about a space
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-space
+func (api *API) GetSpace(spaceKey string, params Parameters) (*Space, error) {
+	result := &Space{}
+	statusCode, err := api.doRequest(
+		"GetSpace", "GET", "/rest/api/space/"+spaceKey,
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoSpace
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetSpaceContent fetch the content in this given space
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-contents
+func (api *API) GetSpaceContent(spaceKey string, params SpaceParameters) (*Contents, error) {
+	result := &Contents{}
+	statusCode, err := api.doRequest(
+		"GetSpaceContent", "GET", "/rest/api/space/"+spaceKey+"/content",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetSpaceContentWithType fetch the content in this given space with the given type
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#space-contentsWithType
+func (api *API) GetSpaceContentWithType(spaceKey, contentType string, params SpaceParameters) (*Contents, error) {
+	result := &Contents{}
+	statusCode, err := api.doRequest(
+		"GetSpaceContentWithType", "GET", "/rest/api/space/"+spaceKey+"/content/"+contentType,
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetUser fetch information about a user identified by either user key or username
+// https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#user-getUser
+func (api *API) GetUser(params UserParameters) (*User, error) {
+	if api.isCloud && params.AccountID == "" {
+		return nil, ErrNoAccountID
+	}
+
 	result := &User{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/user",
+		"GetUser", "GET", "/rest/api/user",
 		params, result, nil,
 	)
 
@@ -733,7 +1272,7 @@ func (api *API) GetUser(params UserParameters) (*User, error) {
 func (api *API) GetAnonymousUser() (*User, error) {
 	result := &User{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/user/anonymous",
+		"GetAnonymousUser", "GET", "/rest/api/user/anonymous",
 		emptyParams, result, nil,
 	)
 
@@ -756,7 +1295,7 @@ func (api *API) GetAnonymousUser() (*User, error) {
 func (api *API) GetCurrentUser(params ExpandParameters) (*User, error) {
 	result := &User{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/user/current",
+		"GetCurrentUser", "GET", "/rest/api/user/current",
 		params, result, nil,
 	)
 
@@ -777,9 +1316,13 @@ func (api *API) GetCurrentUser(params ExpandParameters) (*User, error) {
 // GetUserGroups fetch collection of groups that the given user is a member of
 // https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#user-getGroups
 func (api *API) GetUserGroups(params UserParameters) (*GroupCollection, error) {
+	if api.isCloud && params.AccountID == "" {
+		return nil, ErrNoAccountID
+	}
+
 	result := &GroupCollection{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/user/memberof",
+		"GetUserGroups", "GET", "/rest/api/user/memberof",
 		params, result, nil,
 	)
 
@@ -797,12 +1340,58 @@ func (api *API) GetUserGroups(params UserParameters) (*GroupCollection, error) {
 	}
 }
 
+// UpdateUser updates details of user with given username (or account ID in
+// Confluence Cloud mode)
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/admin/user-updateUser
+func (api *API) UpdateUser(username string, details *UserDetails) (*User, error) {
+	result := &User{}
+	statusCode, err := api.doRequest(
+		"UpdateUser", "PUT", "/rest/api/admin/user/"+url.PathEscape(username),
+		emptyParams, result, details,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 204:
+		if api.isCloud {
+			return api.GetUser(UserParameters{AccountID: username})
+		}
+
+		return api.GetUser(UserParameters{Username: username})
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrNoAdminPerms
+	case 404:
+		return nil, ErrNoUserFound
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// DisableUser disables user with given username
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/admin/user-disableUser
+func (api *API) DisableUser(username string) error {
+	return api.setUserState("DisableUser", username, "disable")
+}
+
+// EnableUser enables previously disabled user with given username
+// https://docs.atlassian.com/ConfluenceServer/rest/8.5.0/#api/admin/user-enableUser
+func (api *API) EnableUser(username string) error {
+	return api.setUserState("EnableUser", username, "enable")
+}
+
 // IsWatchingContent fetch information about whether a user is watching a specified content
 // https://docs.atlassian.com/ConfluenceServer/rest/7.3.4/#user/watch-isWatchingContent
 func (api *API) IsWatchingContent(contentID string, params WatchParameters) (*WatchStatus, error) {
 	result := &WatchStatus{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/user/watch/content/"+contentID,
+		"IsWatchingContent", "GET", "/rest/api/user/watch/content/"+contentID,
 		params, result, nil,
 	)
 
@@ -827,7 +1416,7 @@ func (api *API) IsWatchingContent(contentID string, params WatchParameters) (*Wa
 func (api *API) IsWatchingSpace(spaceKey string, params WatchParameters) (*WatchStatus, error) {
 	result := &WatchStatus{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/user/watch/space/"+spaceKey,
+		"IsWatchingSpace", "GET", "/rest/api/user/watch/space/"+spaceKey,
 		params, result, nil,
 	)
 
@@ -851,7 +1440,7 @@ func (api *API) IsWatchingSpace(spaceKey string, params WatchParameters) (*Watch
 func (api *API) ListWatchers(params ListWatchersParameters) (*WatchInfo, error) {
 	result := &WatchInfo{}
 	statusCode, err := api.doRequest(
-		"GET", "/json/listwatchers.action",
+		"ListWatchers", "GET", "/json/listwatchers.action",
 		params, result, nil,
 	)
 
@@ -871,10 +1460,174 @@ func (api *API) ListWatchers(params ListWatchersParameters) (*WatchInfo, error)
 	}
 }
 
+// GetWebhooks fetch list of webhooks
+func (api *API) GetWebhooks(params WebhookParameters) (*WebhookCollection, error) {
+	result := &WebhookCollection{}
+	statusCode, err := api.doRequest(
+		"GetWebhooks", "GET", "/rest/api/webhooks",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetWebhook fetch webhook with given ID
+func (api *API) GetWebhook(webhookID int) (*Webhook, error) {
+	result := &Webhook{}
+	statusCode, err := api.doRequest(
+		"GetWebhook", "GET", "/rest/api/webhooks/"+strconv.Itoa(webhookID),
+		emptyParams, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoWebhook
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// CreateWebhook creates new webhook
+func (api *API) CreateWebhook(webhook *Webhook) (*Webhook, error) {
+	if webhook == nil {
+		return nil, ErrNilWebhook
+	}
+
+	result := &Webhook{}
+	statusCode, err := api.doRequest(
+		"CreateWebhook", "POST", "/rest/api/webhooks",
+		emptyParams, result, webhook,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200, 201:
+		return result, nil
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrNoPerms
+	case 409:
+		return nil, ErrWebhookExists
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// UpdateWebhook updates webhook with ID from given webhook
+func (api *API) UpdateWebhook(webhook *Webhook) (*Webhook, error) {
+	if webhook == nil {
+		return nil, ErrNilWebhook
+	}
+
+	result := &Webhook{}
+	statusCode, err := api.doRequest(
+		"UpdateWebhook", "PUT", "/rest/api/webhooks/"+strconv.Itoa(webhook.ID),
+		emptyParams, result, webhook,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoWebhook
+	case 409:
+		return nil, ErrWebhookExists
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// DeleteWebhook deletes webhook with given ID
+func (api *API) DeleteWebhook(webhookID int) error {
+	statusCode, err := api.doRequest(
+		"DeleteWebhook", "DELETE", "/rest/api/webhooks/"+strconv.Itoa(webhookID),
+		emptyParams, nil, nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoWebhook
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// TestWebhook sends test request to given webhook endpoint
+func (api *API) TestWebhook(params WebhookTestParameters) (*WebhookTestResult, error) {
+	result := &WebhookTestResult{}
+	statusCode, err := api.doRequest(
+		"TestWebhook", "POST", "/rest/api/webhooks/test",
+		params, result, nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrBadRequest
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// IsCloud returns true if API works with Confluence Cloud
+func (api *API) IsCloud() bool {
+	return api.isCloud
+}
+
 // ProfileURL return link to profile
 func (api *API) ProfileURL(u *User) string {
+	if api.isCloud {
+		return api.url + "/people/" + u.AccountID
+	}
+
 	return api.url + "/display/~" + u.Name
 }
 
@@ -909,33 +1662,105 @@ func (api *API) GenTinyLink(contentID string) string {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// setUserState enables or disables user with given username
+func (api *API) setUserState(operation, username, action string) error {
+	statusCode, err := api.doRequest(
+		operation, "PUT", "/rest/api/admin/user/"+url.PathEscape(username)+"/"+action,
+		emptyParams, nil, nil,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 403:
+		return ErrNoAdminPerms
+	case 404:
+		return ErrNoUserFound
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
 // codebeat:disable[ARITY]
 
 // doRequest create and execute request
-func (api *API) doRequest(method, uri string, params Parameters, result, body interface{}) (int, error) {
+func (api *API) doRequest(operation, method, uri string, params Parameters, result, body interface{}) (int, error) {
 	err := params.Validate()
 
 	if err != nil {
 		return -1, err
 	}
 
-	req := api.acquireRequest(method, uri, params)
+	_, isWriter := result.(io.Writer)
+
+	if api.flights == nil || method != "GET" || body != nil || isWriter {
+		return api.sendRequest(operation, method, uri, params, result, body)
+	}
+
+	key := operation + " " + uri + "?" + params.ToQuery()
+
+	statusCode, data, err := api.flights.do(key, func() (int, []byte, error) {
+		raw := &rawResult{}
+		statusCode, err := api.sendRequest(operation, method, uri, params, raw, body)
+		return statusCode, raw.data, err
+	})
+
+	if err != nil || (statusCode != 200 && statusCode != 201) || result == nil {
+		return statusCode, err
+	}
+
+	return statusCode, json.Unmarshal(data, result)
+}
+
+// sendRequest create and send request
+func (api *API) sendRequest(operation, method, uri string, params Parameters, result, body interface{}) (int, error) {
+	_, isWriter := result.(io.Writer)
+	req, err := api.acquireRequest(method, uri, params)
+
+	if err != nil {
+		return -1, err
+	}
+
 	resp := fasthttp.AcquireResponse()
 
 	defer fasthttp.ReleaseRequest(req)
 	defer fasthttp.ReleaseResponse(resp)
 
-	if body != nil {
+	switch b := body.(type) {
+	case nil:
+		// nop
+	case *rawBody:
+		req.Header.SetContentType(b.ContentType)
+		req.SetBody(b.Data)
+	default:
 		bodyData, err := json.Marshal(body)
 
 		if err != nil {
 			return -1, err
 		}
 
+		req.Header.SetContentType("application/json")
 		req.SetBody(bodyData)
 	}
 
-	err = api.Client.Do(req, resp)
+	call := &Call{
+		Context:   api.ctx,
+		Operation: operation,
+		Method:    method,
+		URI:       uri,
+		Params:    params,
+		Request:   req,
+		Response:  resp,
+		isStream:  isWriter,
+	}
+
+	err = api.execute(call)
+
+	api.logCall(call, err)
 
 	if err != nil {
 		return -1, err
@@ -943,7 +1768,15 @@ func (api *API) doRequest(method, uri string, params Parameters, result, body in
 
 	statusCode := resp.StatusCode()
 
-	if statusCode != 200 || result == nil {
+	if (statusCode != 200 && statusCode != 201) || result == nil {
+		return statusCode, nil
+	}
+
+	switch r := result.(type) {
+	case io.Writer:
+		return statusCode, resp.BodyWriteTo(r)
+	case *rawResult:
+		r.data = append([]byte(nil), resp.Body()...)
 		return statusCode, nil
 	}
 
@@ -955,7 +1788,7 @@ func (api *API) doRequest(method, uri string, params Parameters, result, body in
 // codebeat:enable[ARITY]
 
 // acquireRequest acquire new request with given params
-func (api *API) acquireRequest(method, uri string, params Parameters) *fasthttp.Request {
+func (api *API) acquireRequest(method, uri string, params Parameters) (*fasthttp.Request, error) {
 	req := fasthttp.AcquireRequest()
 	query := params.ToQuery()
 
@@ -968,14 +1801,29 @@ func (api *API) acquireRequest(method, uri string, params Parameters) *fasthttp.
 
 	if method != "GET" {
 		req.Header.SetMethod(method)
+		req.Header.Set("X-Atlassian-Token", "no-check")
 	}
 
-	// Set authorization header
-	if api.auth != "" {
-		req.Header.Add("Authorization", api.auth)
+	for name, value := range api.headers {
+		req.Header.Set(name, value)
 	}
 
-	return req
+	// Set authorization data
+	switch auth := api.auth.(type) {
+	case nil:
+		// nop
+	case Authorizer:
+		err := auth.Authorize(req)
+
+		if err != nil {
+			fasthttp.ReleaseRequest(req)
+			return nil, err
+		}
+	default:
+		req.Header.Add("Authorization", auth.Encode())
+	}
+
+	return req, nil
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -997,6 +1845,37 @@ func getUserAgent(app, version string) string {
 	)
 }
 
+// encodeAttachment encodes attachment as multipart form
+func encodeAttachment(attachment *AttachmentInput) (*rawBody, error) {
+	if attachment == nil || attachment.FileName == "" {
+		return nil, errors.New("Attachment file name can't be empty")
+	}
+
+	buf := &bytes.Buffer{}
+	mw := multipart.NewWriter(buf)
+	fw, err := mw.CreateFormFile("file", attachment.FileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fw.Write(attachment.Data)
+
+	if attachment.Comment != "" {
+		mw.WriteField("comment", attachment.Comment)
+	}
+
+	mw.WriteField("minorEdit", strconv.FormatBool(attachment.IsMinorEdit))
+
+	err = mw.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rawBody{mw.FormDataContentType(), buf.Bytes()}, nil
+}
+
 // makeUnknownError create error struct for unknown error
 func makeUnknownError(statusCode int) error {
 	return fmt.Errorf("Unknown error occurred (status code %d)", statusCode)
@@ -1074,6 +1953,7 @@ func convertWatcherToUser(w *Watcher) *User {
 		Type:        w.Type,
 		Name:        w.Name,
 		Key:         w.Key,
+		AccountID:   w.AccountID,
 		DisplayName: w.DisplayName,
 		ProfilePicture: &Icon{
 			Path:      w.AvatarURL,
@@ -0,0 +1,459 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Environment variables with credentials
+const (
+	ENV_URL       = "CONFLUENCE_URL"
+	ENV_TOKEN     = "CONFLUENCE_TOKEN"
+	ENV_USER      = "CONFLUENCE_USER"
+	ENV_PASSWORD  = "CONFLUENCE_PASSWORD"
+	ENV_EMAIL     = "CONFLUENCE_EMAIL"
+	ENV_API_TOKEN = "CONFLUENCE_API_TOKEN"
+	ENV_PROFILE   = "CONFLUENCE_PROFILE"
+	ENV_CONFIG    = "CONFLUENCE_CONFIG"
+)
+
+// Credentials sources
+const (
+	SOURCE_ENV     = "env"
+	SOURCE_PROFILE = "profile"
+	SOURCE_NETRC   = "netrc"
+)
+
+// _DEFAULT_PROFILE is name of default profile
+const _DEFAULT_PROFILE = "default"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Credentials contains Confluence URL and authorization data
+type Credentials struct {
+	URL     string // Confluence URL
+	Auth    Auth   // Authorization data
+	Source  string // Source of credentials (env, profile or netrc)
+	IsCloud bool   // Confluence Cloud flag
+}
+
+// CredentialsResolver resolves credentials from different sources. Sources are
+// checked in the following order:
+//
+//  1. Environment variables (CONFLUENCE_URL with CONFLUENCE_TOKEN,
+//     CONFLUENCE_USER and CONFLUENCE_PASSWORD or CONFLUENCE_EMAIL and
+//     CONFLUENCE_API_TOKEN)
+//  2. Profile from YAML/JSON configuration file
+//  3. netrc file entry for the host from CONFLUENCE_URL or profile URL
+type CredentialsResolver struct {
+	// Profile is name of profile (CONFLUENCE_PROFILE or profile marked as
+	// default in the configuration file is used if empty)
+	Profile string
+
+	// ConfigFile is path to the configuration file with profiles (CONFLUENCE_CONFIG
+	// or ~/.config/confluence/config.yml is used if empty)
+	ConfigFile string
+
+	// NetrcFile is path to netrc file (NETRC or ~/.netrc is used if empty)
+	NetrcFile string
+
+	// Getenv is function for reading environment variables (os.Getenv is used if nil)
+	Getenv func(key string) string
+}
+
+// ProfilesConfig contains configuration with profiles
+type ProfilesConfig struct {
+	Default  string              `yaml:"default" json:"default"`
+	Profiles map[string]*Profile `yaml:"profiles" json:"profiles"`
+}
+
+// Profile contains profile credentials
+type Profile struct {
+	URL      string `yaml:"url" json:"url"`
+	Token    string `yaml:"token" json:"token"`
+	User     string `yaml:"user" json:"user"`
+	Password string `yaml:"password" json:"password"`
+	Email    string `yaml:"email" json:"email"`
+	APIToken string `yaml:"api_token" json:"api_token"`
+	IsCloud  bool   `yaml:"cloud" json:"cloud"`
+}
+
+// netrcEntry contains netrc machine entry
+type netrcEntry struct {
+	Machine  string
+	Login    string
+	Password string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrNoCredentials is returned if credentials not found in any source
+var ErrNoCredentials = errors.New("Can't find Confluence credentials in environment, profiles or netrc")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ResolveCredentials resolves credentials using default resolver
+func ResolveCredentials() (*Credentials, error) {
+	return CredentialsResolver{}.Resolve()
+}
+
+// Resolve resolves credentials from environment variables, profiles or netrc
+func (r CredentialsResolver) Resolve() (*Credentials, error) {
+	creds, err := r.fromEnv()
+
+	if creds != nil || err != nil {
+		return creds, err
+	}
+
+	creds, err = r.fromProfile()
+
+	if creds != nil || err != nil {
+		return creds, err
+	}
+
+	creds, err = r.fromNetrc()
+
+	if creds != nil || err != nil {
+		return creds, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// NewAPI creates new API instance with credentials
+func (c *Credentials) NewAPI() (*API, error) {
+	if c.IsCloud {
+		return NewCloudAPI(c.URL, c.Auth)
+	}
+
+	return NewAPI(c.URL, c.Auth)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// fromEnv resolves credentials from environment variables
+func (r CredentialsResolver) fromEnv() (*Credentials, error) {
+	p := &Profile{
+		URL:      r.getenv(ENV_URL),
+		Token:    r.getenv(ENV_TOKEN),
+		User:     r.getenv(ENV_USER),
+		Password: r.getenv(ENV_PASSWORD),
+		Email:    r.getenv(ENV_EMAIL),
+		APIToken: r.getenv(ENV_API_TOKEN),
+	}
+
+	if p.Token == "" && p.User == "" && p.Password == "" &&
+		p.Email == "" && p.APIToken == "" {
+		return nil, nil
+	}
+
+	return p.toCredentials(SOURCE_ENV)
+}
+
+// fromProfile resolves credentials from configuration file
+func (r CredentialsResolver) fromProfile() (*Credentials, error) {
+	file := r.getConfigFile()
+
+	if file == "" {
+		return nil, nil
+	}
+
+	config, err := ReadProfilesConfig(file)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist) && r.ConfigFile == "" && r.getenv(ENV_CONFIG) == "":
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	name := r.getProfileName(config)
+	profile := config.Profiles[name]
+
+	if profile == nil {
+		if r.Profile == "" && r.getenv(ENV_PROFILE) == "" && config.Default == "" {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("Profile %q not found in %s", name, file)
+	}
+
+	if profile.URL == "" {
+		profile.URL = r.getenv(ENV_URL)
+	}
+
+	// Profile may contain only URL, credentials in this case are taken from netrc
+	if profile.Token == "" && profile.User == "" && profile.Password == "" &&
+		profile.Email == "" && profile.APIToken == "" {
+		return nil, nil
+	}
+
+	return profile.toCredentials(SOURCE_PROFILE + ":" + name)
+}
+
+// fromNetrc resolves credentials from netrc file
+func (r CredentialsResolver) fromNetrc() (*Credentials, error) {
+	confluenceURL, isCloud := r.getenv(ENV_URL), false
+
+	if confluenceURL == "" {
+		if profile := r.getProfileWithURL(); profile != nil {
+			confluenceURL, isCloud = profile.URL, profile.IsCloud
+		}
+	}
+
+	if confluenceURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(confluenceURL)
+
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("Invalid Confluence URL %q", confluenceURL)
+	}
+
+	file := r.getNetrcFile()
+
+	if file == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("Can't read netrc file: %w", err)
+	}
+
+	entry := findNetrcEntry(parseNetrc(string(data)), u.Hostname())
+
+	if entry == nil {
+		return nil, nil
+	}
+
+	p := &Profile{URL: confluenceURL, IsCloud: isCloud}
+
+	if isCloud || isCloudHost(u.Hostname()) {
+		p.Email, p.APIToken = entry.Login, entry.Password
+	} else {
+		p.User, p.Password = entry.Login, entry.Password
+	}
+
+	return p.toCredentials(SOURCE_NETRC)
+}
+
+// getProfileWithURL returns selected profile if it exists
+func (r CredentialsResolver) getProfileWithURL() *Profile {
+	config, err := ReadProfilesConfig(r.getConfigFile())
+
+	if err != nil {
+		return nil
+	}
+
+	return config.Profiles[r.getProfileName(config)]
+}
+
+// getProfileName returns name of profile
+func (r CredentialsResolver) getProfileName(config *ProfilesConfig) string {
+	switch {
+	case r.Profile != "":
+		return r.Profile
+	case r.getenv(ENV_PROFILE) != "":
+		return r.getenv(ENV_PROFILE)
+	case config.Default != "":
+		return config.Default
+	}
+
+	return _DEFAULT_PROFILE
+}
+
+// getConfigFile returns path to configuration file
+func (r CredentialsResolver) getConfigFile() string {
+	switch {
+	case r.ConfigFile != "":
+		return r.ConfigFile
+	case r.getenv(ENV_CONFIG) != "":
+		return r.getenv(ENV_CONFIG)
+	}
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "confluence", "config.yml")
+}
+
+// getNetrcFile returns path to netrc file
+func (r CredentialsResolver) getNetrcFile() string {
+	switch {
+	case r.NetrcFile != "":
+		return r.NetrcFile
+	case r.getenv("NETRC") != "":
+		return r.getenv("NETRC")
+	}
+
+	dir, err := os.UserHomeDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, ".netrc")
+}
+
+// getenv returns value of environment variable
+func (r CredentialsResolver) getenv(key string) string {
+	if r.Getenv != nil {
+		return r.Getenv(key)
+	}
+
+	return os.Getenv(key)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadProfilesConfig reads configuration with profiles from YAML or JSON file
+func ReadProfilesConfig(file string) (*ProfilesConfig, error) {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read profiles file: %w", err)
+	}
+
+	config := &ProfilesConfig{}
+
+	// JSON is a subset of YAML, so YAML decoder can decode both formats
+	err = yaml.Unmarshal(data, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode profiles file %s: %w", file, err)
+	}
+
+	return config, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// toCredentials converts profile to credentials
+func (p *Profile) toCredentials(source string) (*Credentials, error) {
+	if p.URL == "" {
+		return nil, fmt.Errorf("Invalid credentials in %s: %w", source, ErrEmptyURL)
+	}
+
+	creds := &Credentials{URL: p.URL, Source: source, IsCloud: p.IsCloud}
+
+	switch {
+	case p.Email != "" || p.APIToken != "":
+		creds.Auth = AuthCloud{p.Email, p.APIToken}
+		creds.IsCloud = true
+	case p.Token != "":
+		creds.Auth = AuthToken{p.Token}
+	default:
+		creds.Auth = AuthBasic{p.User, p.Password}
+	}
+
+	err := creds.Auth.Validate()
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid credentials in %s: %w", source, err)
+	}
+
+	return creds, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseNetrc parses netrc file data
+func parseNetrc(data string) []*netrcEntry {
+	var result []*netrcEntry
+	var entry *netrcEntry
+
+	lines := strings.Split(data, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		for j := 0; j < len(fields); j++ {
+			switch fields[j] {
+			case "machine", "default":
+				entry = &netrcEntry{}
+
+				if fields[j] == "machine" && j+1 < len(fields) {
+					entry.Machine = fields[j+1]
+					j++
+				}
+
+				result = append(result, entry)
+
+			case "login", "password", "account":
+				if entry == nil || j+1 >= len(fields) {
+					continue
+				}
+
+				switch fields[j] {
+				case "login":
+					entry.Login = fields[j+1]
+				case "password":
+					entry.Password = fields[j+1]
+				}
+
+				j++
+
+			case "macdef":
+				// Skip macro definition until empty line
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+
+				j = len(fields)
+			}
+		}
+	}
+
+	return result
+}
+
+// findNetrcEntry returns entry for given host or default entry
+func findNetrcEntry(entries []*netrcEntry, host string) *netrcEntry {
+	var defaultEntry *netrcEntry
+
+	for _, e := range entries {
+		switch {
+		case e.Machine == host:
+			return e
+		case e.Machine == "" && defaultEntry == nil:
+			defaultEntry = e
+		}
+	}
+
+	return defaultEntry
+}
+
+// isCloudHost returns true if given host is Confluence Cloud host
+func isCloudHost(host string) bool {
+	return strings.HasSuffix(host, ".atlassian.net")
+}
@@ -0,0 +1,665 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Diff operations
+const (
+	DIFF_EQUAL  = "equal"
+	DIFF_INSERT = "insert"
+	DIFF_DELETE = "delete"
+)
+
+// _DIFF_CONTEXT is default number of context lines in unified diff
+const _DIFF_CONTEXT = 3
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ContentDiff contains changes between two versions of content
+type ContentDiff struct {
+	ContentID   string
+	FromVersion *Content      // Older version of content
+	ToVersion   *Content      // Newer version of content
+	Storage     []*DiffChange // Block-level changes of storage format
+	Text        []*DiffChange // Line-level changes of rendered view text
+	Summary     *DiffSummary  // Summary of changes
+}
+
+// DiffChange is one record of edit script
+type DiffChange struct {
+	Op      string // Operation (DIFF_EQUAL, DIFF_INSERT or DIFF_DELETE)
+	OldLine int    // Number of line/block in older version (0 for inserted)
+	NewLine int    // Number of line/block in newer version (0 for deleted)
+	Element string // Name of block element (only for storage format changes)
+	Text    string // Line or block data
+}
+
+// DiffSummary contains summary of changes
+type DiffSummary struct {
+	OldTitle           string
+	NewTitle           string
+	AddedLabels        []string
+	RemovedLabels      []string
+	AddedAttachments   []string // Attachments created between versions
+	UpdatedAttachments []string // Attachments updated between versions
+	BlocksAdded        int
+	BlocksRemoved      int
+	LinesAdded         int
+	LinesRemoved       int
+}
+
+// storageBlock is top-level block of storage format
+type storageBlock struct {
+	Element string
+	Data    string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrInvalidVersion is returned if version number is invalid
+var ErrInvalidVersion = errors.New("Version number must be greater than 0")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// diffBlockElements is list of HTML elements which starts new line of text
+var diffBlockElements = []string{
+	"address", "article", "blockquote", "br", "dd", "div", "dl", "dt", "figcaption",
+	"figure", "h1", "h2", "h3", "h4", "h5", "h6", "hr", "li", "ol", "p", "pre",
+	"section", "table", "td", "th", "tr", "ul",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DiffContentVersions compares two versions of content with given ID. Labels
+// summary is based on labels returned for every version. Attachments summary is
+// based on attachment creation and modification dates, so deleted attachments
+// are not reported.
+func (api *API) DiffContentVersions(contentID string, fromVersion, toVersion int) (*ContentDiff, error) {
+	if fromVersion <= 0 || toVersion <= 0 {
+		return nil, ErrInvalidVersion
+	}
+
+	from, err := api.getContentVersion(contentID, fromVersion)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch version %d: %w", fromVersion, err)
+	}
+
+	to, err := api.getContentVersion(contentID, toVersion)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch version %d: %w", toVersion, err)
+	}
+
+	diff := &ContentDiff{
+		ContentID:   contentID,
+		FromVersion: from,
+		ToVersion:   to,
+		Summary:     &DiffSummary{OldTitle: from.Title, NewTitle: to.Title},
+	}
+
+	diff.Storage = diffStorage(getBodyValue(from, false), getBodyValue(to, false))
+	diff.Text = diffLines(getViewLines(getBodyValue(from, true)), getViewLines(getBodyValue(to, true)))
+
+	diff.Summary.BlocksAdded, diff.Summary.BlocksRemoved = countDiffChanges(diff.Storage)
+	diff.Summary.LinesAdded, diff.Summary.LinesRemoved = countDiffChanges(diff.Text)
+	diff.Summary.AddedLabels, diff.Summary.RemovedLabels = diffLabels(from, to)
+
+	err = api.diffAttachments(diff)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsTitleChanged returns true if title was changed
+func (s *DiffSummary) IsTitleChanged() bool {
+	return s.OldTitle != s.NewTitle
+}
+
+// IsChanged returns true if diff contains any changes
+func (d *ContentDiff) IsChanged() bool {
+	s := d.Summary
+
+	return s.IsTitleChanged() || s.BlocksAdded+s.BlocksRemoved+s.LinesAdded+s.LinesRemoved != 0 ||
+		len(s.AddedLabels)+len(s.RemovedLabels) != 0 ||
+		len(s.AddedAttachments)+len(s.UpdatedAttachments) != 0
+}
+
+// StorageUnified returns unified diff of storage format
+func (d *ContentDiff) StorageUnified() string {
+	oldName, newName := d.getFileNames()
+	return UnifiedDiff(d.Storage, oldName, newName, _DIFF_CONTEXT)
+}
+
+// TextUnified returns unified diff of rendered view text
+func (d *ContentDiff) TextUnified() string {
+	oldName, newName := d.getFileNames()
+	return UnifiedDiff(d.Text, oldName, newName, _DIFF_CONTEXT)
+}
+
+// getFileNames returns names of versions used in unified diff headers
+func (d *ContentDiff) getFileNames() (string, string) {
+	return fmt.Sprintf("%s (version %d)", d.FromVersion.Title, getContentVersionNumber(d.FromVersion)),
+		fmt.Sprintf("%s (version %d)", d.ToVersion.Title, getContentVersionNumber(d.ToVersion))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UnifiedDiff formats edit script as unified diff with given number of context
+// lines. Multiline records (e.g. storage format blocks) are written line by line.
+func UnifiedDiff(changes []*DiffChange, oldName, newName string, context int) string {
+	if context < 0 {
+		context = 0
+	}
+
+	var buf strings.Builder
+
+	// Line positions of every record in old and new versions
+	oldPos, newPos := make([]int, len(changes)+1), make([]int, len(changes)+1)
+
+	for i, c := range changes {
+		lines := strings.Count(c.Text, "\n") + 1
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+
+		if c.Op != DIFF_INSERT {
+			oldPos[i+1] += lines
+		}
+
+		if c.Op != DIFF_DELETE {
+			newPos[i+1] += lines
+		}
+	}
+
+	for i := 0; i < len(changes); {
+		if changes[i].Op == DIFF_EQUAL {
+			i++
+			continue
+		}
+
+		start, end := max(0, i-context), i
+
+		// Extend hunk while gaps between changes are covered by context
+		for j := i; j < len(changes); j++ {
+			if changes[j].Op != DIFF_EQUAL {
+				end = j + 1
+			} else if j-end >= context*2 {
+				break
+			}
+		}
+
+		end = min(len(changes), end+context)
+
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+		}
+
+		fmt.Fprintf(
+			&buf, "@@ -%s +%s @@\n",
+			formatHunkRange(oldPos[start], oldPos[end]-oldPos[start]),
+			formatHunkRange(newPos[start], newPos[end]-newPos[start]),
+		)
+
+		for _, c := range changes[start:end] {
+			prefix := " "
+
+			switch c.Op {
+			case DIFF_INSERT:
+				prefix = "+"
+			case DIFF_DELETE:
+				prefix = "-"
+			}
+
+			for _, line := range strings.Split(c.Text, "\n") {
+				buf.WriteString(prefix + line + "\n")
+			}
+		}
+
+		i = end
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getContentVersion fetches given version of content with bodies and labels
+func (api *API) getContentVersion(contentID string, version int) (*Content, error) {
+	return api.GetContentByID(contentID, ContentIDParameters{
+		Version: version,
+		Expand:  []string{"body.storage", "body.view", "version", "metadata.labels"},
+	})
+}
+
+// diffAttachments adds info about attachment changes to summary
+func (api *API) diffAttachments(diff *ContentDiff) error {
+	from, to := getContentVersionDate(diff.FromVersion), getContentVersionDate(diff.ToVersion)
+
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return nil
+	}
+
+	params := AttachmentParameters{Expand: []string{"version", "history"}, Limit: _EXPORT_PAGE_SIZE}
+
+	for {
+		attachments, err := api.GetAttachments(diff.ContentID, params)
+
+		if err != nil {
+			return fmt.Errorf("Can't fetch attachments: %w", err)
+		}
+
+		for _, a := range attachments.Results {
+			created := getContentCreationDate(a)
+			modified := getContentVersionDate(a)
+
+			switch {
+			case !created.IsZero() && created.After(from) && !created.After(to):
+				diff.Summary.AddedAttachments = append(diff.Summary.AddedAttachments, a.Title)
+			case !modified.IsZero() && modified.After(from) && !modified.After(to):
+				diff.Summary.UpdatedAttachments = append(diff.Summary.UpdatedAttachments, a.Title)
+			}
+		}
+
+		if isLastPage(attachments.Size, attachments.Limit, params.Limit) {
+			return nil
+		}
+
+		params.Start += attachments.Size
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// diffStorage compares storage format bodies at block level
+func diffStorage(oldBody, newBody string) []*DiffChange {
+	oldBlocks, newBlocks := splitStorageBlocks(oldBody), splitStorageBlocks(newBody)
+	oldData, newData := make([]string, len(oldBlocks)), make([]string, len(newBlocks))
+
+	for i, b := range oldBlocks {
+		oldData[i] = b.Data
+	}
+
+	for i, b := range newBlocks {
+		newData[i] = b.Data
+	}
+
+	changes := diffLines(oldData, newData)
+
+	for _, c := range changes {
+		if c.NewLine != 0 {
+			c.Element = newBlocks[c.NewLine-1].Element
+		} else {
+			c.Element = oldBlocks[c.OldLine-1].Element
+		}
+	}
+
+	return changes
+}
+
+// diffLines compares two lists of lines using longest common subsequence
+func diffLines(oldLines, newLines []string) []*DiffChange {
+	var result []*DiffChange
+
+	// Common prefix and suffix are excluded from comparison
+	prefix := 0
+
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	for i := 0; i < prefix; i++ {
+		result = append(result, &DiffChange{Op: DIFF_EQUAL, OldLine: i + 1, NewLine: i + 1, Text: oldLines[i]})
+	}
+
+	a, b := oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix]
+	i, j := 0, 0
+
+	// Lines between matched pairs are reported as deletions followed by insertions
+	for _, m := range append(matchLines(a, b, 0, 0, nil), [2]int{len(a), len(b)}) {
+		for ; i < m[0]; i++ {
+			result = append(result, &DiffChange{Op: DIFF_DELETE, OldLine: prefix + i + 1, Text: a[i]})
+		}
+
+		for ; j < m[1]; j++ {
+			result = append(result, &DiffChange{Op: DIFF_INSERT, NewLine: prefix + j + 1, Text: b[j]})
+		}
+
+		if i < len(a) && j < len(b) {
+			result = append(result, &DiffChange{
+				Op: DIFF_EQUAL, OldLine: prefix + i + 1, NewLine: prefix + j + 1, Text: a[i],
+			})
+			i++
+			j++
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		oi, ni := len(oldLines)-suffix+k, len(newLines)-suffix+k
+		result = append(result, &DiffChange{
+			Op: DIFF_EQUAL, OldLine: oi + 1, NewLine: ni + 1, Text: oldLines[oi],
+		})
+	}
+
+	return result
+}
+
+// matchLines finds longest common subsequence of two lists of lines using
+// Hirschberg's algorithm and appends positions of matched lines (shifted by given
+// offsets) to result. Algorithm uses linear memory.
+func matchLines(a, b []string, aOffset, bOffset int, result [][2]int) [][2]int {
+	switch {
+	case len(a) == 0 || len(b) == 0:
+		return result
+	case len(a) == 1:
+		if j := slices.Index(b, a[0]); j != -1 {
+			result = append(result, [2]int{aOffset, bOffset + j})
+		}
+
+		return result
+	}
+
+	mid := len(a) / 2
+	head := getLCSLengths(a[:mid], b, false)
+	tail := getLCSLengths(a[mid:], b, true)
+
+	// Split b at point with max total length of common subsequence
+	split, best := 0, -1
+
+	for k := 0; k <= len(b); k++ {
+		if head[k]+tail[len(b)-k] > best {
+			split, best = k, head[k]+tail[len(b)-k]
+		}
+	}
+
+	result = matchLines(a[:mid], b[:split], aOffset, bOffset, result)
+
+	return matchLines(a[mid:], b[split:], aOffset+mid, bOffset+split, result)
+}
+
+// getLCSLengths returns lengths of longest common subsequences of a and every
+// prefix of b (or every suffix of a and b if reverse is true)
+func getLCSLengths(a, b []string, reverse bool) []int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+
+	for i := range a {
+		ai := a[i]
+
+		if reverse {
+			ai = a[len(a)-1-i]
+		}
+
+		for j := 1; j <= len(b); j++ {
+			bj := b[j-1]
+
+			if reverse {
+				bj = b[len(b)-j]
+			}
+
+			if ai == bj {
+				cur[j] = prev[j-1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j-1])
+			}
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev
+}
+
+// splitStorageBlocks splits storage format body into top-level blocks. If body
+// can't be parsed, every line is used as a block.
+func splitStorageBlocks(body string) []*storageBlock {
+	var result []*storageBlock
+
+	src := "<root>" + body + "</root>"
+	decoder := xml.NewDecoder(strings.NewReader(src))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	depth, start := 0, int64(0)
+	element := ""
+
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+
+		if err == io.EOF {
+			return result
+		}
+
+		if err != nil {
+			return splitStorageLines(body)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			if depth == 2 {
+				start, element = offset, getXMLName(t.Name)
+			}
+
+		case xml.EndElement:
+			if depth == 2 {
+				result = append(result, &storageBlock{element, src[start:decoder.InputOffset()]})
+			}
+
+			depth--
+
+		default:
+			if depth != 1 {
+				continue
+			}
+
+			data := strings.TrimSpace(src[offset:decoder.InputOffset()])
+
+			if data != "" {
+				result = append(result, &storageBlock{"", data})
+			}
+		}
+	}
+}
+
+// splitStorageLines splits storage format body into non-empty lines
+func splitStorageLines(body string) []*storageBlock {
+	var result []*storageBlock
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, &storageBlock{"", line})
+		}
+	}
+
+	return result
+}
+
+// getViewLines converts rendered view to lines of text
+func getViewLines(view string) []string {
+	var buf strings.Builder
+	var skip bool
+
+	tokenizer := html.NewTokenizer(strings.NewReader(view))
+
+LOOP:
+	for {
+		tokenType := tokenizer.Next()
+
+		switch tokenType {
+		case html.ErrorToken:
+			break LOOP
+
+		case html.TextToken:
+			if !skip {
+				buf.Write(tokenizer.Text())
+			}
+
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+
+			switch {
+			case tag == "script" || tag == "style":
+				skip = tokenType == html.StartTagToken
+			case slices.Contains(diffBlockElements, tag):
+				buf.WriteString("\n")
+			}
+		}
+	}
+
+	var result []string
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
+
+// diffLabels returns names of added and removed labels
+func diffLabels(from, to *Content) ([]string, []string) {
+	oldLabels, newLabels := getLabelNames(from), getLabelNames(to)
+
+	var added, removed []string
+
+	for _, l := range newLabels {
+		if !slices.Contains(oldLabels, l) {
+			added = append(added, l)
+		}
+	}
+
+	for _, l := range oldLabels {
+		if !slices.Contains(newLabels, l) {
+			removed = append(removed, l)
+		}
+	}
+
+	return added, removed
+}
+
+// countDiffChanges returns number of inserted and deleted records
+func countDiffChanges(changes []*DiffChange) (int, int) {
+	var inserted, deleted int
+
+	for _, c := range changes {
+		switch c.Op {
+		case DIFF_INSERT:
+			inserted++
+		case DIFF_DELETE:
+			deleted++
+		}
+	}
+
+	return inserted, deleted
+}
+
+// formatHunkRange formats range of lines for hunk header
+func formatHunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// getBodyValue returns storage format or rendered view of content body
+func getBodyValue(content *Content, view bool) string {
+	switch {
+	case content.Body == nil:
+		return ""
+	case view && content.Body.View != nil:
+		return content.Body.View.Value
+	case !view && content.Body.StorageView != nil:
+		return content.Body.StorageView.Value
+	}
+
+	return ""
+}
+
+// getLabelNames returns names of content labels
+func getLabelNames(content *Content) []string {
+	var result []string
+
+	if content.Metadata == nil || content.Metadata.Labels == nil {
+		return nil
+	}
+
+	for _, l := range content.Metadata.Labels.Result {
+		result = append(result, l.Name)
+	}
+
+	return result
+}
+
+// getContentVersionNumber returns number of content version
+func getContentVersionNumber(content *Content) int {
+	if content.Version == nil {
+		return 0
+	}
+
+	return content.Version.Number
+}
+
+// getContentVersionDate returns date of content version
+func getContentVersionDate(content *Content) time.Time {
+	if content.Version == nil || content.Version.When == nil {
+		return time.Time{}
+	}
+
+	return content.Version.When.Time
+}
+
+// getContentCreationDate returns date of content creation
+func getContentCreationDate(content *Content) time.Time {
+	if content.History == nil || content.History.CreatedDate == nil {
+		return time.Time{}
+	}
+
+	return content.History.CreatedDate.Time
+}
+
+// getXMLName returns name of XML element with prefix
+func getXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,723 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Export directory layout
+const (
+	EXPORT_MANIFEST_FILE     = "manifest.json"
+	EXPORT_SPACE_FILE        = "space.json"
+	EXPORT_CONTENT_FILE      = "content.json"
+	EXPORT_BODY_FILE         = "body.storage.xml"
+	EXPORT_RESTRICTIONS_FILE = "restrictions.json"
+	EXPORT_PROPERTIES_FILE   = "properties.json"
+	EXPORT_ATTACHMENTS_FILE  = "attachments.json"
+	EXPORT_ATTACHMENTS_DIR   = "attachments"
+	EXPORT_PAGES_DIR         = "pages"
+	EXPORT_BLOGPOSTS_DIR     = "blogposts"
+)
+
+// _EXPORT_FORMAT_VERSION is version of export format (2 - content properties)
+const _EXPORT_FORMAT_VERSION = 2
+
+// _EXPORT_PAGE_SIZE is number of items fetched with one request
+const _EXPORT_PAGE_SIZE = 100
+
+// _EXPORT_SAVE_INTERVAL is number of exported items after which manifest is saved
+const _EXPORT_SAVE_INTERVAL = 25
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExportParameters is params for space export
+type ExportParameters struct {
+	// Types is list of exported content types (pages and blog posts by default)
+	Types []string
+
+	// Concurrency is max number of concurrently exported items (8 is used if 0)
+	Concurrency int
+
+	// SkipAttachments disables export of attachments
+	SkipAttachments bool
+
+	// Prune enables removing of previously exported content which was deleted
+	// from the space
+	Prune bool
+}
+
+// ExportManifest contains info about exported space
+type ExportManifest struct {
+	FormatVersion int                     `json:"formatVersion"`
+	Space         *Space                  `json:"space"`
+	Started       time.Time               `json:"started"`
+	Finished      time.Time               `json:"finished,omitzero"`
+	Content       map[string]*ExportEntry `json:"content"`
+}
+
+// ExportEntry contains info about exported content
+type ExportEntry struct {
+	ID          string                       `json:"id"`
+	Type        string                       `json:"type"`
+	Title       string                       `json:"title"`
+	ParentID    string                       `json:"parentId,omitempty"`
+	Path        string                       `json:"path"`
+	Version     int                          `json:"version"`
+	Labels      []string                     `json:"labels,omitempty"`
+	Attachments map[string]*ExportAttachment `json:"attachments,omitempty"`
+}
+
+// ExportAttachment contains info about exported attachment
+type ExportAttachment struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	File      string `json:"file"`
+	MediaType string `json:"mediaType"`
+	Size      int    `json:"size"`
+	Version   int    `json:"version"`
+}
+
+// ExportReport contains export statistics
+type ExportReport struct {
+	Exported    int // Number of exported content items
+	Skipped     int // Number of unchanged content items
+	Moved       int // Number of moved content items
+	Removed     int // Number of removed content items
+	Attachments int // Number of downloaded attachments
+}
+
+// spaceExporter contains space export state
+type spaceExporter struct {
+	api      *API
+	dir      string
+	params   ExportParameters
+	manifest *ExportManifest
+	report   *ExportReport
+	moved    map[string]bool
+	changes  int
+	outdated bool // Export was created with older format version
+	mu       sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrUnsupportedExport is returned if export has unsupported format version
+var ErrUnsupportedExport = errors.New("Export has unsupported format version")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExportSpace exports all pages and blog posts from space with given key to given
+// directory. Content directories mirror page hierarchy. Export is resumable and
+// incremental: content with unchanged version and labels and attachments with
+// unchanged version are not downloaded again.
+func (api *API) ExportSpace(spaceKey, dir string, params ExportParameters) (*ExportReport, error) {
+	if len(params.Types) == 0 {
+		params.Types = []string{CONTENT_TYPE_PAGE, CONTENT_TYPE_BLOGPOST}
+	}
+
+	space, err := api.GetSpace(spaceKey, ExpandParameters{Expand: []string{"homepage"}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := ReadExportManifest(dir)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		manifest = &ExportManifest{Content: map[string]*ExportEntry{}}
+	case err != nil:
+		return nil, err
+	}
+
+	outdated := manifest.FormatVersion < _EXPORT_FORMAT_VERSION
+
+	manifest.FormatVersion = _EXPORT_FORMAT_VERSION
+	manifest.Space = space
+	manifest.Started = time.Now().UTC()
+	manifest.Finished = time.Time{}
+
+	e := &spaceExporter{
+		api:      api,
+		dir:      dir,
+		params:   params,
+		manifest: manifest,
+		report:   &ExportReport{},
+		moved:    map[string]bool{},
+		outdated: outdated,
+	}
+
+	err = e.export(spaceKey)
+
+	if err != nil {
+		e.saveManifest()
+		return e.report, err
+	}
+
+	e.manifest.Finished = time.Now().UTC()
+
+	return e.report, e.saveManifest()
+}
+
+// ReadExportManifest reads manifest of export in given directory
+func ReadExportManifest(dir string) (*ExportManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, EXPORT_MANIFEST_FILE))
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ExportManifest{}
+	err = json.Unmarshal(data, manifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode export manifest: %w", err)
+	}
+
+	if manifest.FormatVersion > _EXPORT_FORMAT_VERSION {
+		return nil, ErrUnsupportedExport
+	}
+
+	if manifest.Content == nil {
+		manifest.Content = map[string]*ExportEntry{}
+	}
+
+	return manifest, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// export exports all content from the space
+func (e *spaceExporter) export(spaceKey string) error {
+	err := writeJSONFile(filepath.Join(e.dir, EXPORT_SPACE_FILE), e.manifest.Space)
+
+	if err != nil {
+		return err
+	}
+
+	var items []*Content
+
+	for _, contentType := range e.params.Types {
+		content, err := e.api.getAllContent(ContentParameters{
+			Type:     contentType,
+			SpaceKey: spaceKey,
+			Expand:   []string{"version", "ancestors", "metadata.labels"},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		items = append(items, content...)
+	}
+
+	entries := make([]*ExportEntry, len(items))
+
+	for i, c := range items {
+		entries[i] = getExportEntry(c)
+	}
+
+	err = e.move(entries)
+
+	if err != nil {
+		return err
+	}
+
+	e.prune(entries)
+
+	var errs []error
+	var errMu sync.Mutex
+
+	forEachParallel(len(items), e.params.Concurrency, func(i int) {
+		err := e.exportContent(items[i], entries[i])
+
+		if err != nil {
+			errMu.Lock()
+			errs = append(errs, fmt.Errorf("Can't export content %s: %w", items[i].ID, err))
+			errMu.Unlock()
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// move moves directories of content with changed path
+func (e *spaceExporter) move(entries []*ExportEntry) error {
+	sorted := slices.Clone(entries)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.Count(sorted[i].Path, "/") < strings.Count(sorted[j].Path, "/")
+	})
+
+	for _, entry := range sorted {
+		prev := e.manifest.Content[entry.ID]
+
+		if prev == nil || prev.Path == entry.Path {
+			continue
+		}
+
+		oldDir := filepath.Join(e.dir, filepath.FromSlash(prev.Path))
+		newDir := filepath.Join(e.dir, filepath.FromSlash(entry.Path))
+
+		if _, err := os.Stat(oldDir); err != nil {
+			continue
+		}
+
+		err := os.MkdirAll(filepath.Dir(newDir), 0755)
+
+		if err == nil {
+			err = os.Rename(oldDir, newDir)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Can't move content %s: %w", entry.ID, err)
+		}
+
+		// Directories of descendants were moved with the parent
+		for _, child := range e.manifest.Content {
+			if strings.HasPrefix(child.Path, prev.Path+"/") {
+				child.Path = entry.Path + strings.TrimPrefix(child.Path, prev.Path)
+			}
+		}
+
+		prev.Path = entry.Path
+		e.moved[entry.ID] = true
+		e.report.Moved++
+	}
+
+	return nil
+}
+
+// prune removes content which doesn't exist anymore
+func (e *spaceExporter) prune(entries []*ExportEntry) {
+	if !e.params.Prune {
+		return
+	}
+
+	exists := map[string]bool{}
+
+	for _, entry := range entries {
+		exists[entry.ID] = true
+	}
+
+	for id, entry := range e.manifest.Content {
+		if exists[id] {
+			continue
+		}
+
+		dir := filepath.Join(e.dir, filepath.FromSlash(entry.Path))
+
+		// Remove only exported files, because directory can contain directories
+		// of children
+		for _, file := range []string{
+			EXPORT_CONTENT_FILE, EXPORT_BODY_FILE, EXPORT_RESTRICTIONS_FILE,
+			EXPORT_PROPERTIES_FILE, EXPORT_ATTACHMENTS_FILE,
+		} {
+			os.Remove(filepath.Join(dir, file))
+		}
+
+		os.RemoveAll(filepath.Join(dir, EXPORT_ATTACHMENTS_DIR))
+		os.Remove(dir)
+
+		delete(e.manifest.Content, id)
+		e.report.Removed++
+	}
+}
+
+// exportContent exports one content item
+func (e *spaceExporter) exportContent(item *Content, entry *ExportEntry) error {
+	dir := filepath.Join(e.dir, filepath.FromSlash(entry.Path))
+
+	e.mu.Lock()
+	prev := e.manifest.Content[item.ID]
+	e.mu.Unlock()
+
+	// Moved content must be exported again because ancestors were changed, and
+	// content from exports with older format must be exported again because
+	// they don't contain all files
+	isChanged := prev == nil || e.outdated || e.moved[item.ID] ||
+		prev.Version != entry.Version || !slices.Equal(prev.Labels, entry.Labels) ||
+		prev.Path != entry.Path || !isFileExist(filepath.Join(dir, EXPORT_CONTENT_FILE)) ||
+		!isFileExist(filepath.Join(dir, EXPORT_PROPERTIES_FILE))
+
+	if prev != nil {
+		entry.Attachments = prev.Attachments
+	}
+
+	if isChanged {
+		err := e.writeContent(item.ID, dir)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if !e.params.SkipAttachments {
+		err := e.exportAttachments(item.ID, dir, entry)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.manifest.Content[item.ID] = entry
+
+	if isChanged {
+		e.report.Exported++
+	} else {
+		e.report.Skipped++
+	}
+
+	e.changes++
+
+	if e.changes%_EXPORT_SAVE_INTERVAL == 0 {
+		return e.saveManifestUnsafe()
+	}
+
+	return nil
+}
+
+// writeContent fetches and writes content data
+func (e *spaceExporter) writeContent(contentID, dir string) error {
+	content, err := e.api.GetContentByID(contentID, ContentIDParameters{
+		Expand: []string{
+			"body.storage", "version", "ancestors", "space",
+			"metadata.labels", "history",
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	restrictions, err := e.api.GetRestrictionsByOperation(contentID, ExpandParameters{
+		Expand: []string{"restrictions.user", "restrictions.group"},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	properties, err := e.api.getAllContentProperties(contentID)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONFile(filepath.Join(dir, EXPORT_PROPERTIES_FILE), properties)
+
+	if err != nil {
+		return err
+	}
+
+	var body string
+
+	if content.Body != nil && content.Body.StorageView != nil {
+		body = content.Body.StorageView.Value
+	}
+
+	err = writeFileAtomic(filepath.Join(dir, EXPORT_BODY_FILE), []byte(body))
+
+	if err != nil {
+		return err
+	}
+
+	err = writeJSONFile(filepath.Join(dir, EXPORT_RESTRICTIONS_FILE), restrictions)
+
+	if err != nil {
+		return err
+	}
+
+	return writeJSONFile(filepath.Join(dir, EXPORT_CONTENT_FILE), content)
+}
+
+// exportAttachments downloads all new and changed attachments of content
+func (e *spaceExporter) exportAttachments(contentID, dir string, entry *ExportEntry) error {
+	var attachments []*Content
+
+	for start := 0; ; {
+		collection, err := e.api.GetAttachments(contentID, AttachmentParameters{
+			Expand: []string{"version"},
+			Start:  start,
+			Limit:  _EXPORT_PAGE_SIZE,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		attachments = append(attachments, collection.Results...)
+
+		if isLastPage(collection.Size, collection.Limit, _EXPORT_PAGE_SIZE) {
+			break
+		}
+
+		start += collection.Size
+	}
+
+	result := map[string]*ExportAttachment{}
+
+	for _, a := range attachments {
+		info := &ExportAttachment{
+			ID:    a.ID,
+			Title: a.Title,
+			File:  EXPORT_ATTACHMENTS_DIR + "/" + a.ID + "-" + sanitizeFileName(a.Title),
+		}
+
+		if a.Version != nil {
+			info.Version = a.Version.Number
+		}
+
+		if a.Extensions != nil {
+			info.MediaType = a.Extensions.MediaType
+			info.Size = a.Extensions.FileSize
+		}
+
+		file := filepath.Join(dir, filepath.FromSlash(info.File))
+		prev := entry.Attachments[a.ID]
+
+		if prev != nil && prev.Version == info.Version && isFileExist(file) {
+			result[a.ID] = info
+			continue
+		}
+
+		err := e.downloadAttachment(a, file)
+
+		if err != nil {
+			return fmt.Errorf("Can't download attachment %s: %w", a.ID, err)
+		}
+
+		if prev != nil && prev.File != info.File {
+			os.Remove(filepath.Join(dir, filepath.FromSlash(prev.File)))
+		}
+
+		result[a.ID] = info
+
+		e.mu.Lock()
+		e.report.Attachments++
+		e.mu.Unlock()
+	}
+
+	// Remove files of deleted attachments
+	for id, prev := range entry.Attachments {
+		if result[id] == nil {
+			os.Remove(filepath.Join(dir, filepath.FromSlash(prev.File)))
+		}
+	}
+
+	entry.Attachments = result
+
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	return writeJSONFile(filepath.Join(dir, EXPORT_ATTACHMENTS_FILE), attachments)
+}
+
+// downloadAttachment downloads attachment data to given file
+func (e *spaceExporter) downloadAttachment(attachment *Content, file string) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".download-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	err = e.api.DownloadAttachment(attachment, tmp)
+
+	if err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
+
+// saveManifest saves export manifest
+func (e *spaceExporter) saveManifest() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.saveManifestUnsafe()
+}
+
+// saveManifestUnsafe saves export manifest without locking
+func (e *spaceExporter) saveManifestUnsafe() error {
+	return writeJSONFile(filepath.Join(e.dir, EXPORT_MANIFEST_FILE), e.manifest)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getAllContent fetches all pages of content collection
+func (api *API) getAllContent(params ContentParameters) ([]*Content, error) {
+	var result []*Content
+
+	params.Limit = _EXPORT_PAGE_SIZE
+
+	for {
+		collection, err := api.GetContent(params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, collection.Results...)
+
+		if isLastPage(collection.Size, collection.Limit, params.Limit) {
+			return result, nil
+		}
+
+		params.Start += collection.Size
+	}
+}
+
+// getAllContentProperties fetches all content properties
+func (api *API) getAllContentProperties(contentID string) ([]*ContentProperty, error) {
+	var result []*ContentProperty
+
+	params := CollectionParameters{Limit: _EXPORT_PAGE_SIZE}
+
+	for {
+		collection, err := api.GetContentProperties(contentID, params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, collection.Results...)
+
+		if isLastPage(collection.Size, collection.Limit, params.Limit) {
+			return result, nil
+		}
+
+		params.Start += collection.Size
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getExportEntry creates export entry for given content
+func getExportEntry(c *Content) *ExportEntry {
+	entry := &ExportEntry{ID: c.ID, Type: c.Type, Title: c.Title}
+
+	if c.Version != nil {
+		entry.Version = c.Version.Number
+	}
+
+	if c.Metadata != nil && c.Metadata.Labels != nil {
+		for _, l := range c.Metadata.Labels.Result {
+			entry.Labels = append(entry.Labels, l.Name)
+		}
+
+		sort.Strings(entry.Labels)
+	}
+
+	if c.Type != CONTENT_TYPE_PAGE {
+		entry.Path = EXPORT_BLOGPOSTS_DIR + "/" + c.ID
+		return entry
+	}
+
+	path := []string{EXPORT_PAGES_DIR}
+
+	for _, a := range c.Ancestors {
+		if a.Type == "" || a.Type == CONTENT_TYPE_PAGE {
+			path = append(path, a.ID)
+			entry.ParentID = a.ID
+		}
+	}
+
+	entry.Path = strings.Join(append(path, c.ID), "/")
+
+	return entry
+}
+
+// writeJSONFile encodes given data to JSON and writes it to file
+func writeJSONFile(file string, data any) error {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(file, jsonData)
+}
+
+// writeFileAtomic writes data to temporary file and renames it
+func writeFileAtomic(file string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+
+	if err != nil {
+		return err
+	}
+
+	tmp := file + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// sanitizeFileName removes characters which can't be used in file names
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', 0:
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+
+	return name
+}
+
+// isFileExist returns true if file exists
+func isFileExist(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
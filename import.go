@@ -0,0 +1,515 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ImportParameters is params for space import
+type ImportParameters struct {
+	// ParentID is ID of page used as parent for top-level pages
+	ParentID string
+
+	// DryRun enables dry-run mode: nothing is created, but report contains all
+	// actions which would be performed
+	DryRun bool
+
+	// SkipAttachments disables import of attachments
+	SkipAttachments bool
+
+	// SkipLabels disables import of labels
+	SkipLabels bool
+
+	// SkipProperties disables import of content properties
+	SkipProperties bool
+}
+
+// ImportReport contains import results
+type ImportReport struct {
+	Created     []*ImportItem     // Created content
+	Skipped     []*ImportItem     // Skipped content
+	IDs         map[string]string // Source content and attachment IDs mapped to new IDs
+	Relinked    int               // Number of content items updated for remapping links
+	Attachments int               // Number of uploaded attachments
+	Labels      int               // Number of added labels
+	Properties  int               // Number of created or updated content properties
+}
+
+// ImportItem contains info about imported content
+type ImportItem struct {
+	SourceID string
+	TargetID string
+	Type     string
+	Title    string
+	Reason   string // Reason of skipping
+}
+
+// spaceImporter contains space import state
+type spaceImporter struct {
+	api      *API
+	dir      string
+	spaceKey string
+	params   ImportParameters
+	manifest *ExportManifest
+	report   *ImportReport
+	known    map[string]bool
+	keyRegex *regexp.Regexp // Regexp for source space key references
+}
+
+// importedContent contains info about content which must be relinked
+type importedContent struct {
+	entry   *ExportEntry
+	content *Content
+	body    string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// idRefRegex is regexp for content and attachment ID references in storage format
+var idRefRegex = regexp.MustCompile(
+	`(pageId=|/pages/|/download/attachments/|/download/thumbnails/|ri:content-id=")([0-9]+)`,
+)
+
+// systemPropertyKeys is list of content properties managed by Confluence
+var systemPropertyKeys = []string{
+	"content-appearance-draft",
+	"content-appearance-published",
+	"editor",
+	"sync-rev",
+	"sync-rev-source",
+	"emoji-title-draft",
+	"emoji-title-published",
+	"content-type",
+}
+
+// systemPropertyPrefixes is list of prefixes of content properties managed by
+// Confluence
+var systemPropertyPrefixes = []string{
+	"cover-picture-width-",
+	"confluence.",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ImportSpace imports content from export in given directory to the space with
+// given key. Pages are created with the same hierarchy, internal links and
+// attachment references are remapped to new IDs. Content with the same title as
+// existing content in the target space is skipped.
+func (api *API) ImportSpace(dir, spaceKey string, params ImportParameters) (*ImportReport, error) {
+	manifest, err := ReadExportManifest(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	i := &spaceImporter{
+		api:      api,
+		dir:      dir,
+		spaceKey: spaceKey,
+		params:   params,
+		manifest: manifest,
+		report:   &ImportReport{IDs: map[string]string{}},
+		known:    map[string]bool{},
+		keyRegex: getSpaceKeyRegex(manifest, spaceKey),
+	}
+
+	return i.report, i.run()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// run imports all content
+func (i *spaceImporter) run() error {
+	entries := getImportOrder(i.manifest)
+
+	for _, entry := range entries {
+		i.known[entry.ID] = true
+
+		for id := range entry.Attachments {
+			i.known[id] = true
+		}
+	}
+
+	var pending []*importedContent
+
+	for _, entry := range entries {
+		imported, err := i.importContent(entry)
+
+		if err != nil {
+			return fmt.Errorf("Can't import content %s: %w", entry.ID, err)
+		}
+
+		if imported != nil {
+			pending = append(pending, imported)
+		}
+	}
+
+	// Content created before content it refers to must be updated
+	for _, imported := range pending {
+		err := i.relink(imported)
+
+		if err != nil {
+			return fmt.Errorf("Can't remap links in content %s: %w", imported.entry.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// importContent imports one content item. If content contains references to content
+// which wasn't imported yet, it will be returned for relinking.
+func (i *spaceImporter) importContent(entry *ExportEntry) (*importedContent, error) {
+	item := &ImportItem{SourceID: entry.ID, Type: entry.Type, Title: entry.Title}
+	existing, err := i.findExisting(entry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		item.TargetID, item.Reason = existing.ID, "Content with the same title already exists"
+		i.report.IDs[entry.ID] = existing.ID
+		i.report.Skipped = append(i.report.Skipped, item)
+		return nil, nil
+	}
+
+	dir := filepath.Join(i.dir, filepath.FromSlash(entry.Path))
+	body, err := os.ReadFile(filepath.Join(dir, EXPORT_BODY_FILE))
+
+	if err != nil {
+		return nil, err
+	}
+
+	remapped, unresolved := i.remap(string(body))
+
+	input := &ContentInput{
+		Type:  entry.Type,
+		Title: entry.Title,
+		Space: &SpaceReference{Key: i.spaceKey},
+		Body:  &BodyInput{Storage: &View{Value: remapped, Representation: "storage"}},
+	}
+
+	parentID := i.params.ParentID
+
+	if entry.ParentID != "" && i.report.IDs[entry.ParentID] != "" {
+		parentID = i.report.IDs[entry.ParentID]
+	}
+
+	if parentID != "" && entry.Type == CONTENT_TYPE_PAGE {
+		input.Ancestors = []*ContentReference{{ID: parentID}}
+	}
+
+	if i.params.DryRun {
+		i.report.Created = append(i.report.Created, item)
+		i.countDryRun(entry, dir)
+		return nil, nil
+	}
+
+	content, err := i.api.CreateContent(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item.TargetID = content.ID
+	i.report.IDs[entry.ID] = content.ID
+	i.report.Created = append(i.report.Created, item)
+
+	err = i.importExtras(entry, content.ID, dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if unresolved {
+		return &importedContent{entry, content, string(body)}, nil
+	}
+
+	return nil, nil
+}
+
+// importExtras imports labels, properties and attachments of content
+func (i *spaceImporter) importExtras(entry *ExportEntry, contentID, dir string) error {
+	if !i.params.SkipLabels && len(entry.Labels) != 0 {
+		_, err := i.api.AddLabels(contentID, entry.Labels)
+
+		if err != nil {
+			return err
+		}
+
+		i.report.Labels += len(entry.Labels)
+	}
+
+	if !i.params.SkipProperties {
+		properties, err := readExportProperties(dir)
+
+		if err != nil {
+			return err
+		}
+
+		for _, p := range properties {
+			err = i.importProperty(contentID, p)
+
+			if err != nil {
+				return fmt.Errorf("Can't import property %q: %w", p.Key, err)
+			}
+
+			i.report.Properties++
+		}
+	}
+
+	if i.params.SkipAttachments {
+		return nil
+	}
+
+	for _, a := range getSortedAttachments(entry) {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(a.File)))
+
+		if err != nil {
+			return err
+		}
+
+		attachment, err := i.api.CreateAttachment(contentID, &AttachmentInput{
+			FileName: a.Title, Data: data, IsMinorEdit: true,
+		})
+
+		if err != nil {
+			return fmt.Errorf("Can't upload attachment %s: %w", a.ID, err)
+		}
+
+		i.report.IDs[a.ID] = attachment.ID
+		i.report.Attachments++
+	}
+
+	return nil
+}
+
+// relink updates content body with remapped references
+func (i *spaceImporter) relink(imported *importedContent) error {
+	body, _ := i.remap(imported.body)
+	version := 1
+
+	if imported.content.Version != nil {
+		version = imported.content.Version.Number
+	}
+
+	_, err := i.api.UpdateContent(imported.content.ID, &ContentInput{
+		Type:    imported.entry.Type,
+		Title:   imported.entry.Title,
+		Space:   &SpaceReference{Key: i.spaceKey},
+		Body:    &BodyInput{Storage: &View{Value: body, Representation: "storage"}},
+		Version: &VersionInput{Number: version + 1, IsMinorEdit: true},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	i.report.Relinked++
+
+	return nil
+}
+
+// findExisting finds content with the same title in the target space
+func (i *spaceImporter) findExisting(entry *ExportEntry) (*Content, error) {
+	content, err := i.api.GetContent(ContentParameters{
+		Type:     entry.Type,
+		SpaceKey: i.spaceKey,
+		Title:    entry.Title,
+		Limit:    1,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content.Results) == 0 {
+		return nil, nil
+	}
+
+	return content.Results[0], nil
+}
+
+// remap replaces source IDs and space key in storage format body. It returns true
+// if body contains references to content which wasn't imported yet.
+func (i *spaceImporter) remap(body string) (string, bool) {
+	var unresolved bool
+
+	body = idRefRegex.ReplaceAllStringFunc(body, func(ref string) string {
+		m := idRefRegex.FindStringSubmatch(ref)
+		newID := i.report.IDs[m[2]]
+
+		if newID == "" {
+			unresolved = unresolved || i.known[m[2]]
+			return ref
+		}
+
+		return m[1] + newID
+	})
+
+	if i.keyRegex != nil {
+		body = i.keyRegex.ReplaceAllString(body, "${1}"+i.spaceKey+"${2}")
+	}
+
+	return body, unresolved
+}
+
+// importProperty creates content property or updates it if property with the
+// same key already exists
+func (i *spaceImporter) importProperty(contentID string, property *ContentProperty) error {
+	_, err := i.api.CreateContentProperty(contentID, &ContentProperty{
+		Key: property.Key, Value: property.Value,
+	})
+
+	if err != ErrVersionConflict {
+		return err
+	}
+
+	current, err := i.api.GetContentProperty(
+		contentID, property.Key, ExpandParameters{Expand: []string{"version"}},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	version := 1
+
+	if current.Version != nil {
+		version = current.Version.Number + 1
+	}
+
+	_, err = i.api.UpdateContentProperty(contentID, &ContentProperty{
+		Key: property.Key, Value: property.Value,
+		Version: &VersionInput{Number: version},
+	})
+
+	return err
+}
+
+// countDryRun updates report counters in dry-run mode
+func (i *spaceImporter) countDryRun(entry *ExportEntry, dir string) {
+	if !i.params.SkipLabels {
+		i.report.Labels += len(entry.Labels)
+	}
+
+	if !i.params.SkipProperties {
+		properties, _ := readExportProperties(dir)
+		i.report.Properties += len(properties)
+	}
+
+	if !i.params.SkipAttachments {
+		i.report.Attachments += len(entry.Attachments)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getImportOrder returns entries in import order: parents before children,
+// pages before blog posts
+func getImportOrder(manifest *ExportManifest) []*ExportEntry {
+	var entries []*ExportEntry
+
+	for _, entry := range manifest.Content {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		ei, ej := entries[i], entries[j]
+
+		if ei.Type != ej.Type {
+			return ei.Type == CONTENT_TYPE_PAGE
+		}
+
+		di, dj := strings.Count(ei.Path, "/"), strings.Count(ej.Path, "/")
+
+		if di != dj {
+			return di < dj
+		}
+
+		return ei.ID < ej.ID
+	})
+
+	return entries
+}
+
+// getSpaceKeyRegex returns regexp for references to source space key or nil if
+// key doesn't change
+func getSpaceKeyRegex(manifest *ExportManifest, spaceKey string) *regexp.Regexp {
+	if manifest.Space == nil || manifest.Space.Key == "" || manifest.Space.Key == spaceKey {
+		return nil
+	}
+
+	return regexp.MustCompile(
+		`(ri:space-key="|/display/|/spaces/)` + regexp.QuoteMeta(manifest.Space.Key) + `(["/])`,
+	)
+}
+
+// getSortedAttachments returns attachments of entry sorted by ID
+func getSortedAttachments(entry *ExportEntry) []*ExportAttachment {
+	var result []*ExportAttachment
+
+	for _, a := range entry.Attachments {
+		result = append(result, a)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// readExportProperties reads exported content properties
+func readExportProperties(dir string) ([]*ContentProperty, error) {
+	data, err := os.ReadFile(filepath.Join(dir, EXPORT_PROPERTIES_FILE))
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var properties []*ContentProperty
+
+	err = json.Unmarshal(data, &properties)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode content properties: %w", err)
+	}
+
+	return slices.DeleteFunc(properties, isSystemProperty), nil
+}
+
+// isSystemProperty returns true if content property is managed by Confluence
+// and must not be imported
+func isSystemProperty(property *ContentProperty) bool {
+	if slices.Contains(systemPropertyKeys, property.Key) {
+		return true
+	}
+
+	for _, prefix := range systemPropertyPrefixes {
+		if strings.HasPrefix(property.Key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _LOG_BODY_SNIPPET_SIZE is max size of response body snippet in logs
+const _LOG_BODY_SNIPPET_SIZE = 512
+
+// _LOG_REDACTED is placeholder for redacted values
+const _LOG_REDACTED = "REDACTED"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sensitiveQueryParams contains names of query parameters which must not be logged
+var sensitiveQueryParams = []string{
+	"password", "os_password", "token", "access_token", "refresh_token",
+	"oauth_signature", "oauth_token", "secret", "api_key", "apikey",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// logCall writes info about executed call to the logger
+func (api *API) logCall(call *Call, err error) {
+	if api.Logger == nil {
+		return
+	}
+
+	ctx := call.Context
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	level := slog.LevelDebug
+
+	if err != nil {
+		level = slog.LevelError
+	}
+
+	if !api.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", call.Operation),
+		slog.String("method", call.Method),
+		slog.String("path", string(call.Request.URI().Path())),
+		slog.String("query", redactQuery(string(call.Request.URI().QueryString()))),
+		slog.Duration("duration", call.Duration),
+		slog.Int("attempts", call.Attempts),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		api.Logger.LogAttrs(ctx, level, "Confluence API request failed", attrs...)
+		return
+	}
+
+	statusCode := call.StatusCode()
+	attrs = append(attrs, slog.Int("status", statusCode))
+
+	if statusCode >= 400 {
+		attrs = append(attrs, slog.String("body", getBodySnippet(call.Response.Body())))
+	}
+
+	api.Logger.LogAttrs(ctx, level, "Confluence API request", attrs...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// redactQuery replaces values of sensitive query parameters
+func redactQuery(query string) string {
+	if query == "" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(query)
+
+	if err != nil {
+		return _LOG_REDACTED
+	}
+
+	redacted := false
+
+	for name := range values {
+		if isSensitiveQueryParam(name) {
+			values[name] = []string{_LOG_REDACTED}
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return query
+	}
+
+	return values.Encode()
+}
+
+// isSensitiveQueryParam returns true if query parameter with given name can contain
+// credentials
+func isSensitiveQueryParam(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, p := range sensitiveQueryParams {
+		if name == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBodySnippet returns first bytes of response body
+func getBodySnippet(body []byte) string {
+	if len(body) <= _LOG_BODY_SNIPPET_SIZE {
+		return string(body)
+	}
+
+	body = body[:_LOG_BODY_SNIPPET_SIZE]
+
+	// Don't cut multibyte symbols in half
+	for i := 0; i < utf8.UTFMax && !utf8.Valid(body); i++ {
+		body = body[:len(body)-1]
+	}
+
+	return string(body) + "…"
+}
@@ -0,0 +1,219 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// markdownLinkResolver resolves links and images in Markdown document
+type markdownLinkResolver interface {
+	// resolveLink returns title of page for given link destination
+	resolveLink(dest string) (string, bool)
+
+	// resolveImage returns name of attachment for given image destination
+	resolveImage(dest string) (string, bool)
+}
+
+// storageRenderer renders links, images and code blocks using Confluence
+// storage format elements
+type storageRenderer struct {
+	resolver markdownLinkResolver
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseMarkdown parses Markdown document. If document starts with first level
+// heading, heading is removed from document and returned as title.
+func parseMarkdown(source []byte) (ast.Node, string) {
+	doc := newMarkdown(nil).Parser().Parse(text.NewReader(source))
+	heading, ok := doc.FirstChild().(*ast.Heading)
+
+	if !ok || heading.Level != 1 {
+		return doc, ""
+	}
+
+	title := strings.TrimSpace(getMarkdownText(heading, source))
+	doc.RemoveChild(doc, heading)
+
+	return doc, title
+}
+
+// renderMarkdown renders parsed Markdown document to storage format
+func renderMarkdown(doc ast.Node, source []byte, resolver markdownLinkResolver) (string, error) {
+	var buf bytes.Buffer
+
+	err := newMarkdown(resolver).Renderer().Render(&buf, source, doc)
+
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// newMarkdown creates Markdown converter with storage format renderer
+func newMarkdown(resolver markdownLinkResolver) goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough),
+		goldmark.WithRendererOptions(
+			html.WithXHTML(),
+			renderer.WithNodeRenderers(
+				util.Prioritized(&storageRenderer{resolver}, 100),
+			),
+		),
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RegisterFuncs registers render functions
+func (r *storageRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindLink, r.renderLink)
+	reg.Register(ast.KindImage, r.renderImage)
+	reg.Register(ast.KindCodeBlock, r.renderCodeBlock)
+	reg.Register(ast.KindFencedCodeBlock, r.renderCodeBlock)
+}
+
+// renderLink renders links to other documents as links to pages
+func (r *storageRenderer) renderLink(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Link)
+	dest := string(n.Destination)
+	title, ok := "", false
+
+	if r.resolver != nil {
+		title, ok = r.resolver.resolveLink(dest)
+	}
+
+	if !ok {
+		if entering {
+			w.WriteString(`<a href="`)
+			w.Write(util.EscapeHTML(util.URLEscape(n.Destination, true)))
+			w.WriteString(`">`)
+		} else {
+			w.WriteString("</a>")
+		}
+
+		return ast.WalkContinue, nil
+	}
+
+	if !entering {
+		w.WriteString("</ac:link-body></ac:link>")
+		return ast.WalkContinue, nil
+	}
+
+	w.WriteString("<ac:link")
+
+	if _, anchor, found := strings.Cut(dest, "#"); found && anchor != "" {
+		w.WriteString(` ac:anchor="` + escapeXML(anchor) + `"`)
+	}
+
+	w.WriteString(`><ri:page ri:content-title="` + escapeXML(title) + `"/><ac:link-body>`)
+
+	return ast.WalkContinue, nil
+}
+
+// renderImage renders images as attached or external images
+func (r *storageRenderer) renderImage(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.Image)
+	dest := string(n.Destination)
+	name, ok := "", false
+
+	if r.resolver != nil {
+		name, ok = r.resolver.resolveImage(dest)
+	}
+
+	w.WriteString("<ac:image")
+
+	if alt := getMarkdownText(n, source); alt != "" {
+		w.WriteString(` ac:alt="` + escapeXML(alt) + `"`)
+	}
+
+	if ok {
+		w.WriteString(`><ri:attachment ri:filename="` + escapeXML(name) + `"/></ac:image>`)
+	} else {
+		w.WriteString(`><ri:url ri:value="` + escapeXML(dest) + `"/></ac:image>`)
+	}
+
+	return ast.WalkSkipChildren, nil
+}
+
+// renderCodeBlock renders code blocks as code macro
+func (r *storageRenderer) renderCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	w.WriteString(`<ac:structured-macro ac:name="code">`)
+
+	if n, ok := node.(*ast.FencedCodeBlock); ok && n.Info != nil {
+		lang := string(n.Language(source))
+		w.WriteString(`<ac:parameter ac:name="language">` + escapeXML(lang) + `</ac:parameter>`)
+	}
+
+	w.WriteString("<ac:plain-text-body><![CDATA[")
+
+	lines := node.Lines()
+
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		w.WriteString(strings.ReplaceAll(string(line.Value(source)), "]]>", "]]]]><![CDATA[>"))
+	}
+
+	w.WriteString("]]></ac:plain-text-body></ac:structured-macro>")
+
+	return ast.WalkSkipChildren, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getMarkdownText returns text content of given node
+func getMarkdownText(node ast.Node, source []byte) string {
+	var buf strings.Builder
+
+	ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch t := n.(type) {
+		case *ast.Text:
+			buf.Write(t.Segment.Value(source))
+
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				buf.WriteString(" ")
+			}
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return buf.String()
+}
+
+// escapeXML escapes special characters in attribute value
+func escapeXML(value string) string {
+	return string(util.EscapeHTML([]byte(value)))
+}
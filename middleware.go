@@ -0,0 +1,96 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Call contains info about API call passing through middleware chain
+type Call struct {
+	Context   context.Context    // Call context (see API.WithContext)
+	Operation string             // API method name (e.g. GetContentByID)
+	Method    string             // HTTP method
+	URI       string             // Request URI without Confluence URL
+	Params    Parameters         // Request parameters
+	Request   *fasthttp.Request  // Raw request
+	Response  *fasthttp.Response // Raw response
+	Duration  time.Duration      // Request duration
+	Attempts  int                // Number of times request was sent
+
+	isStream bool // Response body is streamed to io.Writer
+}
+
+// Handler is function which executes API call
+type Handler func(call *Call) error
+
+// handlerWrapper is interface for authorization methods which must handle
+// responses (e.g. for renewing expired sessions)
+type handlerWrapper interface {
+	wrap(next Handler) Handler
+}
+
+// Middleware is function which wraps API call handler. Middleware can modify
+// request before calling next handler, inspect response after it, or
+// short-circuit the call by filling response without calling next handler.
+type Middleware func(next Handler) Handler
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Use appends given middlewares to the API middleware chain. Middlewares are
+// executed in order of adding, so the first added middleware is the outermost.
+func (api *API) Use(middlewares ...Middleware) {
+	for _, m := range middlewares {
+		if m != nil {
+			api.middlewares = append(api.middlewares, m)
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// StatusCode returns response status code
+func (c *Call) StatusCode() int {
+	if c == nil || c.Response == nil {
+		return -1
+	}
+
+	return c.Response.StatusCode()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// execute executes call through middleware chain
+func (api *API) execute(call *Call) error {
+	handler := Handler(api.send)
+
+	if w, ok := api.auth.(handlerWrapper); ok {
+		handler = w.wrap(handler)
+	}
+
+	for i := len(api.middlewares) - 1; i >= 0; i-- {
+		handler = api.middlewares[i](handler)
+	}
+
+	return handler(call)
+}
+
+// send sends request to Confluence
+func (api *API) send(call *Call) error {
+	start := time.Now()
+	err := api.Client.Do(call.Request, call.Response)
+	call.Duration = time.Since(start)
+	call.Attempts++
+
+	return err
+}
@@ -0,0 +1,484 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Mirror event types
+const (
+	MIRROR_EVENT_CREATED MirrorEventType = "created"
+	MIRROR_EVENT_UPDATED MirrorEventType = "updated"
+	MIRROR_EVENT_MOVED   MirrorEventType = "moved"
+	MIRROR_EVENT_DELETED MirrorEventType = "deleted"
+)
+
+// _MIRROR_DATE_FORMAT is format of dates in CQL queries
+const _MIRROR_DATE_FORMAT = "2006/01/02 15:04"
+
+// _MIRROR_DEFAULT_OVERLAP is default overlap of sync intervals
+const _MIRROR_DEFAULT_OVERLAP = 5 * time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MirrorParameters is params for space mirror
+type MirrorParameters struct {
+	// Spaces is list of keys of mirrored spaces
+	Spaces []string
+
+	// Types is list of mirrored content types (pages and blog posts by default)
+	Types []string
+
+	// Expand is list of additional properties to expand for changed content
+	Expand []string
+
+	// StateFile is path to file used for storing checkpoint and info about
+	// mirrored content. If empty, state is kept only in memory.
+	StateFile string
+
+	// Overlap is duration subtracted from checkpoint for compensating minute
+	// precision of CQL dates and clock skew (5 minutes by default)
+	Overlap time.Duration
+
+	// Location is time zone used by Confluence for CQL dates (UTC by default)
+	Location *time.Location
+
+	// PageSize is number of items fetched with one request (100 is used if 0)
+	PageSize int
+
+	// PurgeInterval is interval between checks of all known content. Such check
+	// finds content which was purged from trash or moved to not mirrored space.
+	// Check requires one search request for every PageSize known items, so it
+	// is disabled if 0.
+	PurgeInterval time.Duration
+}
+
+// MirrorEventType is type of mirror event
+type MirrorEventType string
+
+// MirrorEvent contains info about content change
+type MirrorEvent struct {
+	Type        MirrorEventType // Event type
+	Content     *Content        // Changed content (for deleted content only ID, type, title and space are set)
+	SpaceKey    string          // Space key
+	ParentID    string          // ID of parent page
+	OldParentID string          // ID of previous parent page (only for moved content)
+}
+
+// MirrorHandler is function called for every content change
+type MirrorHandler func(event *MirrorEvent) error
+
+// MirrorState contains mirror checkpoint and info about mirrored content
+type MirrorState struct {
+	Checkpoint time.Time              `json:"checkpoint"`
+	PurgeCheck time.Time              `json:"purge_check,omitzero"`
+	Content    map[string]*MirrorItem `json:"content"`
+}
+
+// MirrorItem contains info about mirrored content
+type MirrorItem struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	SpaceKey string `json:"space"`
+	ParentID string `json:"parent,omitempty"`
+	Version  int    `json:"version"`
+}
+
+// Mirror keeps local state of spaces in sync with Confluence. Changes are
+// pulled from Confluence only, local changes are not pushed back.
+type Mirror struct {
+	api    *API
+	params MirrorParameters
+	state  *MirrorState
+	mu     sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrNoMirrorSpaces is returned if list of mirrored spaces is empty
+var ErrNoMirrorSpaces = errors.New("At least one space must be mirrored")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewMirror creates new mirror of spaces with given keys. If state file exists,
+// checkpoint and info about mirrored content are loaded from it.
+func (api *API) NewMirror(params MirrorParameters) (*Mirror, error) {
+	if len(params.Spaces) == 0 {
+		return nil, ErrNoMirrorSpaces
+	}
+
+	if len(params.Types) == 0 {
+		params.Types = []string{CONTENT_TYPE_PAGE, CONTENT_TYPE_BLOGPOST}
+	}
+
+	if params.Overlap == 0 {
+		params.Overlap = _MIRROR_DEFAULT_OVERLAP
+	}
+
+	if params.Location == nil {
+		params.Location = time.UTC
+	}
+
+	if params.PageSize <= 0 {
+		params.PageSize = _EXPORT_PAGE_SIZE
+	}
+
+	state, err := readMirrorState(params.StateFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mirror{api: api, params: params, state: state}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Sync fetches content changed since the last checkpoint and calls fn for every
+// change. The first sync reports all content as created. Known content which
+// was moved to the trash since the last checkpoint is reported as deleted. If
+// PurgeInterval is set, purged and moved to not mirrored spaces content is also
+// reported as deleted. Checkpoint is advanced only if all changes were
+// successfully handled, so failed sync can be safely repeated.
+func (m *Mirror) Sync(fn MirrorHandler) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	started := time.Now()
+	err := m.sync(fn)
+
+	if err == nil {
+		m.state.Checkpoint = started
+	}
+
+	saveErr := m.save()
+
+	if err != nil {
+		return err
+	}
+
+	return saveErr
+}
+
+// Checkpoint returns time of the last successful sync
+func (m *Mirror) Checkpoint() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.state.Checkpoint
+}
+
+// Items returns info about all mirrored content
+func (m *Mirror) Items() []*MirrorItem {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []*MirrorItem
+
+	for _, id := range getSortedKeys(m.state.Content) {
+		item := *m.state.Content[id]
+		result = append(result, &item)
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sync fetches changed and deleted content
+func (m *Mirror) sync(fn MirrorHandler) error {
+	expand := append([]string{"space", "version", "ancestors"}, m.params.Expand...)
+
+	err := m.search(m.getQuery(""), expand, func(content *Content) error {
+		return m.handleChanged(content, fn)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Nothing can be trashed before the first sync
+	if !m.state.Checkpoint.IsZero() {
+		err = m.search(m.getQuery(" and status = trashed"), nil, func(content *Content) error {
+			return m.handleDeleted(content.ID, fn)
+		})
+
+		if err != nil {
+			return fmt.Errorf("Can't fetch trashed content: %w", err)
+		}
+	}
+
+	if m.params.PurgeInterval <= 0 || time.Since(m.state.PurgeCheck) < m.params.PurgeInterval {
+		return nil
+	}
+
+	return m.syncPurged(fn)
+}
+
+// search calls fn for every content found by given CQL query
+func (m *Mirror) search(cql string, expand []string, fn func(content *Content) error) error {
+	start := 0
+
+	for {
+		contents, err := m.api.SearchContent(ContentSearchParameters{
+			CQL:    cql,
+			Expand: expand,
+			Start:  start,
+			Limit:  m.params.PageSize,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, content := range contents.Results {
+			err = fn(content)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if isLastPage(contents.Size, contents.Limit, m.params.PageSize) {
+			return nil
+		}
+
+		start += contents.Size
+	}
+}
+
+// syncPurged checks that all known content is still available in mirrored
+// spaces and emits delete event for purged or moved to other spaces content.
+// Content is checked in batches using CQL queries "id in (…)".
+func (m *Mirror) syncPurged(fn MirrorHandler) error {
+	started := time.Now()
+	ids := getSortedKeys(m.state.Content)
+
+	for len(ids) != 0 {
+		batch := ids[:min(len(ids), m.params.PageSize)]
+		ids = ids[len(batch):]
+
+		found, err := m.findContent(batch)
+
+		if err != nil {
+			return fmt.Errorf("Can't check mirrored content: %w", err)
+		}
+
+		for _, id := range batch {
+			if found[id] {
+				continue
+			}
+
+			err = m.handleDeleted(id, fn)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	m.state.PurgeCheck = started
+
+	return nil
+}
+
+// findContent returns set of IDs of content available in mirrored spaces
+func (m *Mirror) findContent(ids []string) (map[string]bool, error) {
+	result := map[string]bool{}
+	cql := fmt.Sprintf("id in (%s) and %s", strings.Join(ids, ","), m.getScopeQuery())
+	start := 0
+
+	for {
+		contents, err := m.api.SearchContent(ContentSearchParameters{
+			CQL:   cql,
+			Start: start,
+			Limit: len(ids),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range contents.Results {
+			result[content.ID] = true
+		}
+
+		if len(result) == len(ids) || isLastPage(contents.Size, contents.Limit, len(ids)) {
+			return result, nil
+		}
+
+		start += contents.Size
+	}
+}
+
+// handleChanged compares content with known state and emits change event
+func (m *Mirror) handleChanged(content *Content, fn MirrorHandler) error {
+	if content.IsTrashed() {
+		return m.handleDeleted(content.ID, fn)
+	}
+
+	item := getMirrorItem(content)
+	known := m.state.Content[content.ID]
+	event := &MirrorEvent{Content: content, SpaceKey: item.SpaceKey, ParentID: item.ParentID}
+
+	switch {
+	case known == nil:
+		event.Type = MIRROR_EVENT_CREATED
+	case known.ParentID != item.ParentID:
+		event.Type, event.OldParentID = MIRROR_EVENT_MOVED, known.ParentID
+	case item.Version > known.Version || known.Title != item.Title:
+		event.Type = MIRROR_EVENT_UPDATED
+	default:
+		// Content was already handled by previous sync
+		return nil
+	}
+
+	err := fn(event)
+
+	if err != nil {
+		return err
+	}
+
+	m.state.Content[content.ID] = item
+
+	return nil
+}
+
+// handleDeleted emits delete event for known content
+func (m *Mirror) handleDeleted(contentID string, fn MirrorHandler) error {
+	known := m.state.Content[contentID]
+
+	if known == nil {
+		return nil
+	}
+
+	err := fn(&MirrorEvent{
+		Type: MIRROR_EVENT_DELETED,
+		Content: &Content{
+			ID:    known.ID,
+			Type:  known.Type,
+			Title: known.Title,
+		},
+		SpaceKey: known.SpaceKey,
+		ParentID: known.ParentID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	delete(m.state.Content, contentID)
+
+	return nil
+}
+
+// getQuery returns CQL query with given filter for fetching changed content
+func (m *Mirror) getQuery(filter string) string {
+	cql := m.getScopeQuery() + filter
+
+	if !m.state.Checkpoint.IsZero() {
+		since := m.state.Checkpoint.Add(-m.params.Overlap).In(m.params.Location)
+		cql += fmt.Sprintf(" and lastmodified >= %s", quoteCQL(since.Format(_MIRROR_DATE_FORMAT)))
+	}
+
+	return cql + " order by lastmodified asc"
+}
+
+// getScopeQuery returns CQL query for filtering content by mirrored spaces and
+// types
+func (m *Mirror) getScopeQuery() string {
+	var spaces []string
+
+	for _, key := range m.params.Spaces {
+		spaces = append(spaces, quoteCQL(key))
+	}
+
+	return fmt.Sprintf(
+		"space in (%s) and type in (%s)",
+		strings.Join(spaces, ","), strings.Join(m.params.Types, ","),
+	)
+}
+
+// save saves mirror state to state file
+func (m *Mirror) save() error {
+	if m.params.StateFile == "" {
+		return nil
+	}
+
+	return writeJSONFile(m.params.StateFile, m.state)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readMirrorState reads mirror state from given file
+func readMirrorState(file string) (*MirrorState, error) {
+	state := &MirrorState{Content: map[string]*MirrorItem{}}
+
+	if file == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(file)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return state, nil
+	case err != nil:
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, state)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode mirror state: %w", err)
+	}
+
+	if state.Content == nil {
+		state.Content = map[string]*MirrorItem{}
+	}
+
+	return state, nil
+}
+
+// getMirrorItem creates mirror item for given content
+func getMirrorItem(content *Content) *MirrorItem {
+	item := &MirrorItem{
+		ID:    content.ID,
+		Type:  content.Type,
+		Title: content.Title,
+	}
+
+	if content.Space != nil {
+		item.SpaceKey = content.Space.Key
+	}
+
+	if content.Version != nil {
+		item.Version = content.Version.Number
+	}
+
+	if len(content.Ancestors) != 0 {
+		item.ParentID = content.Ancestors[len(content.Ancestors)-1].ID
+	}
+
+	return item
+}
+
+// quoteCQL quotes string value for using in CQL query
+func quoteCQL(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
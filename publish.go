@@ -0,0 +1,768 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PUBLISH_PROPERTY is key of content property with info about published page
+const PUBLISH_PROPERTY = "go-confluence-publish"
+
+// _PUBLISH_CHILDREN_BODY is body of pages created for directories without index file
+const _PUBLISH_CHILDREN_BODY = `<ac:structured-macro ac:name="children"/>`
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PublishParameters is params for publishing directory
+type PublishParameters struct {
+	// Message is version message used for created and updated pages
+	Message string
+
+	// IndexFiles is list of names of files used as directory page content
+	// (index.md and README.md are used if empty)
+	IndexFiles []string
+
+	// ArchiveRemoved enables archiving of published pages which were removed
+	// from directory (supported only by Confluence Cloud)
+	ArchiveRemoved bool
+
+	// Concurrency is max number of concurrent requests used for fetching
+	// published pages (8 is used if 0)
+	Concurrency int
+}
+
+// PublishReport contains publishing results
+type PublishReport struct {
+	Created     []*PublishItem // Created pages
+	Updated     []*PublishItem // Pages with updated content
+	Moved       []*PublishItem // Renamed or moved pages
+	Unchanged   []*PublishItem // Pages without changes
+	Removed     []*PublishItem // Pages removed from directory (archived if ArchiveRemoved is set)
+	Attachments int            // Number of uploaded images
+}
+
+// PublishItem contains info about published page
+type PublishItem struct {
+	ID      string
+	Path    string // Path of source relative to directory
+	OldPath string // Previous path of source (only for moved pages)
+	Title   string
+}
+
+// publishInfo is info about published page stored in content property
+type publishInfo struct {
+	Path        string            `json:"path"`
+	Hash        string            `json:"hash"`
+	BodyHash    string            `json:"bodyHash"`
+	Attachments map[string]string `json:"attachments,omitempty"`
+}
+
+// publishSource is page source
+type publishSource struct {
+	Path        string            // Slash-separated path ("" for root, "dir/" for directories)
+	Parent      string            // Path of parent source
+	File        string            // Path to Markdown file
+	Title       string            // Page title
+	Body        string            // Rendered page body
+	Images      map[string]string // Attachment names mapped to image files
+	Attachments map[string]string // Attachment names mapped to image hashes
+	Hash        string            // Hash of title, body and images
+	BodyHash    string            // Hash of body
+
+	doc    ast.Node
+	source []byte
+	pub    *publisher
+}
+
+// publishedPage is page created by previous publishing
+type publishedPage struct {
+	Content  *Content
+	Info     *publishInfo
+	ParentID string
+}
+
+// publisher contains directory publishing state
+type publisher struct {
+	api       *API
+	dir       string
+	params    PublishParameters
+	report    *PublishReport
+	root      *Content
+	sources   map[string]*publishSource
+	files     map[string]*publishSource
+	published map[string]*publishedPage
+	ids       map[string]string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrNoSpaceInfo is returned if space of root page is unknown
+var ErrNoSpaceInfo = errors.New("Can't find space of root page")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PublishDirectory converges page tree under page with given ID to Markdown files
+// in given directory. Every Markdown file becomes a page, every directory becomes
+// a page with content from index file or with list of children. Title of the page
+// is taken from the first level heading at the beginning of the file or from the
+// file name. Pages are matched to files using content property, so renamed and
+// moved files are moved instead of re-creating. Pages are updated only if content
+// hash is changed. Local images are uploaded as attachments.
+func (api *API) PublishDirectory(dir, rootID string, params PublishParameters) (*PublishReport, error) {
+	if params.ArchiveRemoved && !api.isCloud {
+		return nil, fmt.Errorf("Can't archive removed pages: %w", ErrCloudOnly)
+	}
+
+	if len(params.IndexFiles) == 0 {
+		params.IndexFiles = []string{"index.md", "README.md"}
+	}
+
+	p := &publisher{
+		api:       api,
+		dir:       dir,
+		params:    params,
+		report:    &PublishReport{},
+		sources:   map[string]*publishSource{},
+		files:     map[string]*publishSource{},
+		published: map[string]*publishedPage{},
+		ids:       map[string]string{"": rootID},
+	}
+
+	err := p.scan()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.fetchPublished(rootID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.root.Space == nil || p.root.Space.Key == "" {
+		return nil, ErrNoSpaceInfo
+	}
+
+	err = p.render()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.report, p.run()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// scan finds all Markdown files and directories containing them
+func (p *publisher) scan() error {
+	err := filepath.WalkDir(p.dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(p.dir, file)
+
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+
+		if rel != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		switch {
+		case d.IsDir() && rel == ".":
+			p.sources[""] = &publishSource{pub: p}
+
+		case d.IsDir():
+			p.sources[rel+"/"] = &publishSource{
+				Path: rel + "/", Parent: getPublishParent(rel), Title: d.Name(), pub: p,
+			}
+
+		case !strings.EqualFold(path.Ext(rel), ".md"):
+			return nil
+
+		case slices.Contains(p.params.IndexFiles, d.Name()):
+			src := p.sources[getPublishParent(rel)]
+
+			if src.File == "" || slices.Index(p.params.IndexFiles, d.Name()) <
+				slices.Index(p.params.IndexFiles, filepath.Base(src.File)) {
+				src.File = file
+			}
+
+			p.files[rel] = src
+
+		default:
+			p.sources[rel] = &publishSource{
+				Path:   rel,
+				Parent: getPublishParent(rel),
+				File:   file,
+				Title:  strings.TrimSuffix(d.Name(), path.Ext(rel)),
+				pub:    p,
+			}
+
+			p.files[rel] = p.sources[rel]
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Directories without Markdown files are ignored
+	used := map[string]bool{"": true}
+
+	for _, src := range p.sources {
+		if src.File == "" {
+			continue
+		}
+
+		for parent := src.Path; parent != ""; parent = getPublishParent(strings.TrimSuffix(parent, "/")) {
+			used[parent] = true
+		}
+	}
+
+	for srcPath := range p.sources {
+		if !used[srcPath] {
+			delete(p.sources, srcPath)
+		}
+	}
+
+	return nil
+}
+
+// fetchPublished fetches root page and all published pages under it
+func (p *publisher) fetchPublished(rootID string) error {
+	return p.api.WalkPageTree(rootID, WalkParameters{
+		Expand:      []string{"space", "version", "metadata.properties." + PUBLISH_PROPERTY},
+		Concurrency: p.params.Concurrency,
+	}, func(node *TreeNode) error {
+		if node.Depth == 0 {
+			p.root = node.Content
+		}
+
+		info := getPublishInfo(node.Content)
+
+		if info == nil {
+			return nil
+		}
+
+		page := &publishedPage{Content: node.Content, Info: info}
+
+		if len(node.Ancestors) != 0 {
+			page.ParentID = node.Ancestors[len(node.Ancestors)-1].ID
+		}
+
+		if node.Depth != 0 {
+			p.published[info.Path] = page
+		}
+
+		return nil
+	})
+}
+
+// render parses and renders all Markdown files
+func (p *publisher) render() error {
+	titles := map[string]string{}
+
+	for _, src := range p.sortedSources() {
+		if src.File != "" {
+			source, err := os.ReadFile(src.File)
+
+			if err != nil {
+				return err
+			}
+
+			doc, title := parseMarkdown(source)
+			src.doc, src.source = doc, source
+
+			if title != "" {
+				src.Title = title
+			}
+		}
+
+		if src.Path == "" {
+			src.Title = p.root.Title
+		}
+
+		if titles[src.Title] != "" {
+			return fmt.Errorf("Files %q and %q have the same title %q", titles[src.Title], src.Path, src.Title)
+		}
+
+		titles[src.Title] = src.Path
+	}
+
+	for _, src := range p.sources {
+		err := src.render()
+
+		if err != nil {
+			return fmt.Errorf("Can't render %q: %w", src.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// run creates, updates, moves and archives pages
+func (p *publisher) run() error {
+	for _, src := range p.sortedSources() {
+		err := p.publish(src)
+
+		if err != nil {
+			return fmt.Errorf("Can't publish %q: %w", src.Path, err)
+		}
+	}
+
+	var removed []string
+
+	for _, page := range p.sortedPublished() {
+		p.report.Removed = append(p.report.Removed, &PublishItem{
+			ID: page.Content.ID, Path: page.Info.Path, Title: page.Content.Title,
+		})
+
+		removed = append(removed, page.Content.ID)
+	}
+
+	if !p.params.ArchiveRemoved || len(removed) == 0 {
+		return nil
+	}
+
+	return p.api.ArchivePages(removed)
+}
+
+// publish creates or updates page for given source
+func (p *publisher) publish(src *publishSource) error {
+	if src.Path == "" {
+		if src.File == "" {
+			return nil
+		}
+
+		page := &publishedPage{Content: p.root, Info: getPublishInfo(p.root)}
+
+		if page.Info == nil {
+			page.Info = &publishInfo{}
+		}
+
+		return p.update(src, page, "")
+	}
+
+	parentID := p.ids[src.Parent]
+	page := p.findPublished(src)
+
+	if page == nil {
+		return p.create(src, parentID)
+	}
+
+	return p.update(src, page, parentID)
+}
+
+// create creates new page
+func (p *publisher) create(src *publishSource, parentID string) error {
+	content, err := p.api.CreateContent(&ContentInput{
+		Type:      CONTENT_TYPE_PAGE,
+		Title:     src.Title,
+		Space:     &SpaceReference{Key: p.root.Space.Key},
+		Ancestors: []*ContentReference{{ID: parentID}},
+		Body:      &BodyInput{Storage: &View{Value: src.Body, Representation: "storage"}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	p.ids[src.Path] = content.ID
+
+	err = p.uploadImages(content.ID, src, nil)
+
+	if err != nil {
+		return err
+	}
+
+	err = p.saveInfo(content.ID, src, false)
+
+	if err != nil {
+		return err
+	}
+
+	p.report.Created = append(p.report.Created, &PublishItem{
+		ID: content.ID, Path: src.Path, Title: src.Title,
+	})
+
+	return nil
+}
+
+// update updates page if it was changed, renamed or moved
+func (p *publisher) update(src *publishSource, page *publishedPage, parentID string) error {
+	content := page.Content
+	item := &PublishItem{ID: content.ID, Path: src.Path, Title: src.Title}
+	moved := page.Info.Path != src.Path || (parentID != "" && page.ParentID != parentID)
+
+	p.ids[src.Path] = content.ID
+
+	if !moved && page.Info.Hash == src.Hash && content.Title == src.Title {
+		p.report.Unchanged = append(p.report.Unchanged, item)
+		return nil
+	}
+
+	err := p.uploadImages(content.ID, src, page.Info.Attachments)
+
+	if err != nil {
+		return err
+	}
+
+	version := 1
+
+	if content.Version != nil {
+		version = content.Version.Number
+	}
+
+	input := &ContentInput{
+		Type:    CONTENT_TYPE_PAGE,
+		Title:   src.Title,
+		Space:   &SpaceReference{Key: p.root.Space.Key},
+		Body:    &BodyInput{Storage: &View{Value: src.Body, Representation: "storage"}},
+		Version: &VersionInput{Number: version + 1, Message: p.params.Message},
+	}
+
+	if parentID != "" {
+		input.Ancestors = []*ContentReference{{ID: parentID}}
+	}
+
+	_, err = p.api.UpdateContent(content.ID, input)
+
+	if err != nil {
+		return err
+	}
+
+	err = p.saveInfo(content.ID, src, page.Info.Hash != "")
+
+	if err != nil {
+		return err
+	}
+
+	if moved {
+		item.OldPath = page.Info.Path
+		p.report.Moved = append(p.report.Moved, item)
+	} else {
+		p.report.Updated = append(p.report.Updated, item)
+	}
+
+	return nil
+}
+
+// uploadImages uploads new and changed images
+func (p *publisher) uploadImages(contentID string, src *publishSource, uploaded map[string]string) error {
+	for _, name := range getSortedKeys(src.Images) {
+		if uploaded[name] == src.Attachments[name] {
+			continue
+		}
+
+		data, err := os.ReadFile(src.Images[name])
+
+		if err != nil {
+			return err
+		}
+
+		attachments, err := p.api.GetAttachments(contentID, AttachmentParameters{Filename: name})
+
+		if err != nil {
+			return err
+		}
+
+		input := &AttachmentInput{FileName: name, Data: data, IsMinorEdit: true}
+
+		if len(attachments.Results) == 0 {
+			_, err = p.api.CreateAttachment(contentID, input)
+		} else {
+			_, err = p.api.UpdateAttachmentData(contentID, attachments.Results[0].ID, input)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Can't upload image %q: %w", name, err)
+		}
+
+		p.report.Attachments++
+	}
+
+	return nil
+}
+
+// saveInfo saves info about published page to content property
+func (p *publisher) saveInfo(contentID string, src *publishSource, exists bool) error {
+	value, err := json.Marshal(&publishInfo{
+		Path:        src.Path,
+		Hash:        src.Hash,
+		BodyHash:    src.BodyHash,
+		Attachments: src.Attachments,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	property := &ContentProperty{Key: PUBLISH_PROPERTY, Value: value}
+
+	if !exists {
+		_, err = p.api.CreateContentProperty(contentID, property)
+		return err
+	}
+
+	current, err := p.api.GetContentProperty(contentID, PUBLISH_PROPERTY, ExpandParameters{
+		Expand: []string{"version"},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	property.Version = &VersionInput{Number: 2, IsMinorEdit: true}
+
+	if current.Version != nil {
+		property.Version.Number = current.Version.Number + 1
+	}
+
+	_, err = p.api.UpdateContentProperty(contentID, property)
+
+	return err
+}
+
+// findPublished finds published page for given source. If there is no page with
+// the same path, page with the same body from removed file is used.
+func (p *publisher) findPublished(src *publishSource) *publishedPage {
+	page := p.published[src.Path]
+
+	if page != nil {
+		delete(p.published, src.Path)
+		return page
+	}
+
+	for _, page := range p.sortedPublished() {
+		if p.sources[page.Info.Path] == nil && page.Info.BodyHash == src.BodyHash {
+			delete(p.published, page.Info.Path)
+			return page
+		}
+	}
+
+	return nil
+}
+
+// sortedSources returns sources sorted by depth and path
+func (p *publisher) sortedSources() []*publishSource {
+	var result []*publishSource
+
+	for _, src := range p.sources {
+		result = append(result, src)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		di, dj := getPublishDepth(result[i].Path), getPublishDepth(result[j].Path)
+
+		if di != dj {
+			return di < dj
+		}
+
+		return result[i].Path < result[j].Path
+	})
+
+	return result
+}
+
+// sortedPublished returns published pages without source sorted by path
+func (p *publisher) sortedPublished() []*publishedPage {
+	var result []*publishedPage
+
+	for _, srcPath := range getSortedKeys(p.published) {
+		result = append(result, p.published[srcPath])
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// render renders source body and calculates hashes
+func (s *publishSource) render() error {
+	s.Images, s.Attachments = map[string]string{}, map[string]string{}
+	s.Body = _PUBLISH_CHILDREN_BODY
+
+	if s.doc != nil {
+		body, err := renderMarkdown(s.doc, s.source, s)
+
+		if err != nil {
+			return err
+		}
+
+		s.Body = body
+	}
+
+	hasher := sha256.New()
+	hasher.Write([]byte(s.Title + "\x00" + s.Body))
+
+	for _, name := range getSortedKeys(s.Images) {
+		data, err := os.ReadFile(s.Images[name])
+
+		if err != nil {
+			return err
+		}
+
+		s.Attachments[name] = getHash(data)
+		hasher.Write([]byte("\x00" + name + "\x00" + s.Attachments[name]))
+	}
+
+	s.Hash = hex.EncodeToString(hasher.Sum(nil))
+	s.BodyHash = getHash([]byte(s.Body))
+
+	return nil
+}
+
+// resolveLink returns title of page for link to Markdown file or directory
+func (s *publishSource) resolveLink(dest string) (string, bool) {
+	target, ok := s.resolvePath(dest)
+
+	if !ok {
+		return "", false
+	}
+
+	if src := s.pub.files[target]; src != nil {
+		return src.Title, true
+	}
+
+	if src := s.pub.sources[strings.TrimSuffix(target, "/")+"/"]; src != nil {
+		return src.Title, true
+	}
+
+	return "", false
+}
+
+// resolveImage returns name of attachment for local image
+func (s *publishSource) resolveImage(dest string) (string, bool) {
+	target, ok := s.resolvePath(dest)
+
+	if !ok {
+		return "", false
+	}
+
+	file := filepath.Join(s.pub.dir, filepath.FromSlash(target))
+
+	if !isFileExist(file) {
+		return "", false
+	}
+
+	name := strings.ReplaceAll(target, "/", "_")
+	s.Images[name] = file
+
+	return name, true
+}
+
+// resolvePath returns path of link target relative to directory
+func (s *publishSource) resolvePath(dest string) (string, bool) {
+	u, err := url.Parse(dest)
+
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" ||
+		strings.HasPrefix(u.Path, "/") {
+		return "", false
+	}
+
+	rel, _ := filepath.Rel(s.pub.dir, s.File)
+	target := path.Join(path.Dir(filepath.ToSlash(rel)), u.Path)
+
+	if target == ".." || strings.HasPrefix(target, "../") {
+		return "", false
+	}
+
+	return target, true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getPublishInfo decodes info about published page from content metadata
+func getPublishInfo(content *Content) *publishInfo {
+	if content.Metadata == nil || content.Metadata.Properties == nil {
+		return nil
+	}
+
+	property := content.Metadata.Properties[PUBLISH_PROPERTY]
+
+	if property == nil || len(property.Value) == 0 {
+		return nil
+	}
+
+	info := &publishInfo{}
+
+	if json.Unmarshal(property.Value, info) != nil {
+		return nil
+	}
+
+	return info
+}
+
+// getPublishParent returns path of parent source for given relative path
+func getPublishParent(rel string) string {
+	dir := path.Dir(rel)
+
+	if dir == "." {
+		return ""
+	}
+
+	return dir + "/"
+}
+
+// getPublishDepth returns depth of source with given path
+func getPublishDepth(srcPath string) int {
+	if srcPath == "" {
+		return 0
+	}
+
+	return strings.Count(strings.TrimSuffix(srcPath, "/"), "/") + 1
+}
+
+// getSortedKeys returns sorted keys of given map
+func getSortedKeys[T any](m map[string]T) []string {
+	var result []string
+
+	for k := range m {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// getHash returns SHA-256 hash of given data
+func getHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
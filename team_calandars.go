@@ -179,7 +179,7 @@ var idValidationRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}
 func (api *API) GetCalendarEvents(params CalendarEventsParameters) (*CalendarEventCollection, error) {
 	result := &CalendarEventCollection{}
 	statusCode, err := api.doRequest(
-		"GET", _REST_BASE+"/calendar/events.json",
+		"GetCalendarEvents", "GET", _REST_BASE+"/calendar/events.json",
 		params, result, nil,
 	)
 
@@ -197,7 +197,7 @@ func (api *API) GetCalendarEvents(params CalendarEventsParameters) (*CalendarEve
 func (api *API) GetCalendars(params CalendarsParameters) (*CalendarCollection, error) {
 	result := &CalendarCollection{}
 	statusCode, err := api.doRequest(
-		"GET", _REST_BASE+"/calendar/subcalendars.json",
+		"GetCalendars", "GET", _REST_BASE+"/calendar/subcalendars.json",
 		params, result, nil,
 	)
 
@@ -0,0 +1,294 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _TELEMETRY_SCOPE is instrumentation scope name
+const _TELEMETRY_SCOPE = "github.com/essentialkaos/go-confluence"
+
+// Telemetry attributes
+const (
+	ATTR_OPERATION   = "confluence.operation"
+	ATTR_ENDPOINT    = "confluence.endpoint"
+	ATTR_CONTENT_ID  = "confluence.content.id"
+	ATTR_SPACE_KEY   = "confluence.space.key"
+	ATTR_METHOD      = "http.request.method"
+	ATTR_STATUS_CODE = "http.response.status_code"
+	ATTR_DIRECTION   = "network.io.direction"
+)
+
+// Telemetry metrics
+const (
+	METRIC_REQUEST_DURATION = "confluence.client.request.duration"
+	METRIC_REQUEST_ERRORS   = "confluence.client.request.errors"
+	METRIC_TRANSFERRED      = "confluence.client.transferred"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TelemetryConfig contains OpenTelemetry configuration
+type TelemetryConfig struct {
+	// TracerProvider is provider used for creating spans. Global provider is used
+	// if not set.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider is provider used for recording metrics. Global provider is used
+	// if not set.
+	MeterProvider metric.MeterProvider
+
+	// Propagator is propagator used for injecting trace context into request
+	// headers. Global propagator is used if not set.
+	Propagator propagation.TextMapPropagator
+
+	// Context is parent context for spans of calls without context (see
+	// API.WithContext)
+	Context context.Context
+}
+
+// telemetry contains OpenTelemetry instruments
+type telemetry struct {
+	ctx         context.Context
+	tracer      trace.Tracer
+	propagator  propagation.TextMapPropagator
+	duration    metric.Float64Histogram
+	errors      metric.Int64Counter
+	transferred metric.Int64Counter
+}
+
+// requestHeaderCarrier is carrier for injecting trace context into request headers
+type requestHeaderCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	contentIDRegex = regexp.MustCompile(`^(/rest/api/content|/api/v2/(?:pages|blogposts|footer-comments|inline-comments))/([0-9]+)`)
+	spaceKeyRegex  = regexp.MustCompile(`^/rest/api/(?:user/watch/)?space/([^/?]+)`)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewTelemetryMiddleware creates middleware which creates span for every API call
+// and records request latency, errors and transferred bytes
+func NewTelemetryMiddleware(config TelemetryConfig) (Middleware, error) {
+	var err error
+
+	if config.TracerProvider == nil {
+		config.TracerProvider = otel.GetTracerProvider()
+	}
+
+	if config.MeterProvider == nil {
+		config.MeterProvider = otel.GetMeterProvider()
+	}
+
+	if config.Propagator == nil {
+		config.Propagator = otel.GetTextMapPropagator()
+	}
+
+	if config.Context == nil {
+		config.Context = context.Background()
+	}
+
+	t := &telemetry{
+		ctx:        config.Context,
+		tracer:     config.TracerProvider.Tracer(_TELEMETRY_SCOPE),
+		propagator: config.Propagator,
+	}
+
+	meter := config.MeterProvider.Meter(_TELEMETRY_SCOPE)
+
+	t.duration, err = meter.Float64Histogram(
+		METRIC_REQUEST_DURATION,
+		metric.WithDescription("Duration of Confluence API requests"),
+		metric.WithUnit("s"),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.errors, err = meter.Int64Counter(
+		METRIC_REQUEST_ERRORS,
+		metric.WithDescription("Number of failed Confluence API requests"),
+		metric.WithUnit("{request}"),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.transferred, err = meter.Int64Counter(
+		METRIC_TRANSFERRED,
+		metric.WithDescription("Number of bytes transferred to and from Confluence"),
+		metric.WithUnit("By"),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.middleware, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// middleware is telemetry middleware
+func (t *telemetry) middleware(next Handler) Handler {
+	return func(call *Call) error {
+		attrs := []attribute.KeyValue{
+			attribute.String(ATTR_OPERATION, call.Operation),
+			attribute.String(ATTR_METHOD, call.Method),
+			attribute.String(ATTR_ENDPOINT, getCallEndpoint(call.URI)),
+		}
+
+		parent := call.Context
+
+		if parent == nil {
+			parent = t.ctx
+		}
+
+		ctx, span := t.tracer.Start(
+			parent, "confluence."+call.Operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+			trace.WithAttributes(getCallEntities(call)...),
+		)
+
+		defer span.End()
+
+		t.propagator.Inject(ctx, requestHeaderCarrier{&call.Request.Header})
+
+		err := next(call)
+		statusCode := call.StatusCode()
+
+		if err == nil {
+			attrs = append(attrs, attribute.Int(ATTR_STATUS_CODE, statusCode))
+			span.SetAttributes(attribute.Int(ATTR_STATUS_CODE, statusCode))
+		}
+
+		opts := metric.WithAttributes(attrs...)
+
+		t.duration.Record(ctx, call.Duration.Seconds(), opts)
+
+		t.transferred.Add(
+			ctx, int64(len(call.Request.Body())),
+			metric.WithAttributes(append(attrs, attribute.String(ATTR_DIRECTION, "transmit"))...),
+		)
+
+		t.transferred.Add(
+			ctx, int64(len(call.Response.Body())),
+			metric.WithAttributes(append(attrs, attribute.String(ATTR_DIRECTION, "receive"))...),
+		)
+
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			t.errors.Add(ctx, 1, opts)
+
+		case statusCode >= 400:
+			span.SetStatus(codes.Error, makeUnknownError(statusCode).Error())
+			t.errors.Add(ctx, 1, opts)
+		}
+
+		return err
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCallEndpoint returns URI without query string and with IDs and keys
+// replaced by placeholders
+func getCallEndpoint(uri string) string {
+	if strings.Contains(uri, "?") {
+		uri = uri[:strings.Index(uri, "?")]
+	}
+
+	uri = contentIDRegex.ReplaceAllString(uri, "$1/{id}")
+	uri = spaceKeyRegex.ReplaceAllStringFunc(uri, func(s string) string {
+		return s[:strings.LastIndex(s, "/")+1] + "{key}"
+	})
+
+	return uri
+}
+
+// getCallEntities returns attributes with content ID and space key extracted from
+// call URI and parameters
+func getCallEntities(call *Call) []attribute.KeyValue {
+	var contentID, spaceKey string
+
+	if m := contentIDRegex.FindStringSubmatch(call.URI); m != nil {
+		contentID = m[2]
+	}
+
+	if m := spaceKeyRegex.FindStringSubmatch(call.URI); m != nil {
+		spaceKey = m[1]
+	}
+
+	switch p := call.Params.(type) {
+	case ContentParameters:
+		spaceKey = p.SpaceKey
+	case SpaceParameters:
+		if spaceKey == "" {
+			spaceKey = strings.Join(p.SpaceKey, ",")
+		}
+	case ListWatchersParameters:
+		contentID = p.PageID
+	}
+
+	var result []attribute.KeyValue
+
+	if contentID != "" {
+		result = append(result, attribute.String(ATTR_CONTENT_ID, contentID))
+	}
+
+	if spaceKey != "" {
+		result = append(result, attribute.String(ATTR_SPACE_KEY, spaceKey))
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns value of header with given key
+func (c requestHeaderCarrier) Get(key string) string {
+	return string(c.header.Peek(key))
+}
+
+// Set sets value of header with given key
+func (c requestHeaderCarrier) Set(key, value string) {
+	c.header.Set(key, value)
+}
+
+// Keys returns names of all headers
+func (c requestHeaderCarrier) Keys() []string {
+	var result []string
+
+	for key := range c.header.All() {
+		result = append(result, string(key))
+	}
+
+	return result
+}
@@ -168,3 +168,13 @@ func getTagName(tag string) string {
 func esc(s string) string {
 	return url.QueryEscape(s)
 }
+
+// isLastPage returns true if page of collection with given size is the last one.
+// Server can use lower limit than requested, so limit from response is preferred.
+func isLastPage(size, limit, requested int) bool {
+	if limit <= 0 {
+		limit = requested
+	}
+
+	return size == 0 || size < limit
+}
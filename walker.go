@@ -0,0 +1,313 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _WALK_PAGE_SIZE is default number of children fetched with one request
+const _WALK_PAGE_SIZE = 100
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TreeNode is page tree node
+type TreeNode struct {
+	Content   *Content
+	Ancestors []*Content // Ancestors from the root to the parent
+	Depth     int        // Depth of node (0 for root)
+}
+
+// TreeFilter is page tree filter. If filter returns false, node and all its
+// descendants will be skipped.
+type TreeFilter func(node *TreeNode) bool
+
+// TreeWalkFunc is function called for every visited page tree node
+type TreeWalkFunc func(node *TreeNode) error
+
+// WalkParameters is params for walking page tree
+type WalkParameters struct {
+	// Expand is list of properties to expand for every page
+	Expand []string
+
+	// Filters is list of filters, node is visited only if all filters return true
+	Filters []TreeFilter
+
+	// MaxDepth is max depth of walking (0 means unlimited)
+	MaxDepth int
+
+	// Concurrency is max number of concurrent requests (8 is used if 0)
+	Concurrency int
+
+	// PageSize is number of children fetched with one request (100 is used if 0)
+	PageSize int
+}
+
+// treeWalker contains page tree walking state
+type treeWalker struct {
+	api    *API
+	params WalkParameters
+	fn     TreeWalkFunc
+
+	sem chan struct{}
+	wg  sync.WaitGroup
+	err error
+	mu  sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrSkipChildren is used as return value from TreeWalkFunc to indicate that
+// children of the node must be skipped
+var ErrSkipChildren = errors.New("Skip children")
+
+// ErrNoHomepage is returned if space has no homepage
+var ErrNoHomepage = errors.New("Space has no homepage")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WalkPageTree visits page with given ID and all its descendants. Children are
+// fetched concurrently, but fn is never called concurrently. Parent is always
+// visited before its children, order of siblings is not guaranteed. If fn returns
+// ErrSkipChildren, children of the node will be skipped. Any other error stops
+// walking and is returned.
+func (api *API) WalkPageTree(pageID string, params WalkParameters, fn TreeWalkFunc) error {
+	root, err := api.GetContentByID(pageID, ContentIDParameters{Expand: params.Expand})
+
+	if err != nil {
+		return err
+	}
+
+	return api.walkTree(root, params, fn)
+}
+
+// WalkSpaceTree visits homepage of the space with given key and all its
+// descendants
+func (api *API) WalkSpaceTree(spaceKey string, params WalkParameters, fn TreeWalkFunc) error {
+	space, err := api.GetSpace(spaceKey, ExpandParameters{Expand: []string{"homepage"}})
+
+	if err != nil {
+		return err
+	}
+
+	if space.Homepage == nil || space.Homepage.ID == "" {
+		return ErrNoHomepage
+	}
+
+	return api.WalkPageTree(space.Homepage.ID, params, fn)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Path returns titles of all ancestors and node itself
+func (n *TreeNode) Path() []string {
+	var result []string
+
+	for _, c := range n.Ancestors {
+		result = append(result, c.Title)
+	}
+
+	return append(result, n.Content.Title)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FilterByLabel returns filter which accepts pages with any of given labels.
+// Labels must be expanded using "metadata.labels" in Expand parameter.
+func FilterByLabel(labels ...string) TreeFilter {
+	return func(node *TreeNode) bool {
+		c := node.Content
+
+		if c.Metadata == nil || c.Metadata.Labels == nil {
+			return false
+		}
+
+		for _, l := range c.Metadata.Labels.Result {
+			if slices.Contains(labels, l.Name) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilterByTitle returns filter which accepts pages with title matching given
+// regular expression
+func FilterByTitle(re *regexp.Regexp) TreeFilter {
+	return func(node *TreeNode) bool {
+		return re.MatchString(node.Content.Title)
+	}
+}
+
+// FilterByStatus returns filter which accepts pages with any of given statuses
+func FilterByStatus(statuses ...string) TreeFilter {
+	return func(node *TreeNode) bool {
+		return slices.Contains(statuses, node.Content.Status)
+	}
+}
+
+// InvertFilter returns filter which accepts pages rejected by given filter
+func InvertFilter(filter TreeFilter) TreeFilter {
+	return func(node *TreeNode) bool {
+		return !filter(node)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// walkTree walks tree starting from given root content
+func (api *API) walkTree(root *Content, params WalkParameters, fn TreeWalkFunc) error {
+	if params.Concurrency <= 0 {
+		params.Concurrency = _BULK_DEFAULT_CONCURRENCY
+	}
+
+	if params.PageSize <= 0 {
+		params.PageSize = _WALK_PAGE_SIZE
+	}
+
+	w := &treeWalker{
+		api:    api,
+		params: params,
+		fn:     fn,
+		sem:    make(chan struct{}, params.Concurrency),
+	}
+
+	node := &TreeNode{Content: root}
+
+	if w.accept(node) {
+		w.visit(node)
+	}
+
+	w.wg.Wait()
+
+	return w.err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// accept returns true if node passes all filters
+func (w *treeWalker) accept(node *TreeNode) bool {
+	for _, filter := range w.params.Filters {
+		if filter != nil && !filter(node) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// visit calls walk function for node and starts fetching of its children
+func (w *treeWalker) visit(node *TreeNode) {
+	w.mu.Lock()
+
+	if w.err != nil {
+		w.mu.Unlock()
+		return
+	}
+
+	err := w.fn(node)
+
+	if err != nil && err != ErrSkipChildren {
+		w.err = err
+	}
+
+	w.mu.Unlock()
+
+	if err != nil || (w.params.MaxDepth > 0 && node.Depth >= w.params.MaxDepth) {
+		return
+	}
+
+	w.wg.Add(1)
+
+	go w.walkChildren(node)
+}
+
+// walkChildren fetches and visits children of given node
+func (w *treeWalker) walkChildren(node *TreeNode) {
+	defer w.wg.Done()
+
+	children, err := w.getChildren(node.Content.ID)
+
+	if err != nil {
+		w.setError(err)
+		return
+	}
+
+	ancestors := append(slices.Clip(node.Ancestors), node.Content)
+
+	for _, child := range children {
+		childNode := &TreeNode{
+			Content:   child,
+			Ancestors: ancestors,
+			Depth:     node.Depth + 1,
+		}
+
+		if w.accept(childNode) {
+			w.visit(childNode)
+		}
+	}
+}
+
+// getChildren fetches all child pages of content with given ID
+func (w *treeWalker) getChildren(contentID string) ([]*Content, error) {
+	var result []*Content
+	var start int
+
+	for {
+		if w.isStopped() {
+			return nil, nil
+		}
+
+		w.sem <- struct{}{}
+		children, err := w.api.GetContentChildrenByType(
+			contentID, CONTENT_TYPE_PAGE, ChildrenParameters{
+				Expand: w.params.Expand,
+				Start:  start,
+				Limit:  w.params.PageSize,
+			},
+		)
+		<-w.sem
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, children.Results...)
+
+		if isLastPage(children.Size, children.Limit, w.params.PageSize) {
+			return result, nil
+		}
+
+		start += children.Size
+	}
+}
+
+// setError sets walking error if it wasn't set before
+func (w *treeWalker) setError(err error) {
+	w.mu.Lock()
+
+	if w.err == nil {
+		w.err = err
+	}
+
+	w.mu.Unlock()
+}
+
+// isStopped returns true if walking was stopped due to error
+func (w *treeWalker) isStopped() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err != nil
+}
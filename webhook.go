@@ -0,0 +1,510 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Webhook event types
+const (
+	WEBHOOK_EVENT_ANY WebhookEvent = "*"
+
+	WEBHOOK_EVENT_PING WebhookEvent = "diagnostics:ping"
+
+	WEBHOOK_EVENT_PAGE_CREATED            WebhookEvent = "page_created"
+	WEBHOOK_EVENT_PAGE_UPDATED            WebhookEvent = "page_updated"
+	WEBHOOK_EVENT_PAGE_MOVED              WebhookEvent = "page_moved"
+	WEBHOOK_EVENT_PAGE_TRASHED            WebhookEvent = "page_trashed"
+	WEBHOOK_EVENT_PAGE_RESTORED           WebhookEvent = "page_restored"
+	WEBHOOK_EVENT_PAGE_REMOVED            WebhookEvent = "page_removed"
+	WEBHOOK_EVENT_PAGE_VIEWED             WebhookEvent = "page_viewed"
+	WEBHOOK_EVENT_PAGE_CHILDREN_REORDERED WebhookEvent = "page_children_reordered"
+	WEBHOOK_EVENT_BLOG_CREATED            WebhookEvent = "blog_created"
+	WEBHOOK_EVENT_BLOG_UPDATED            WebhookEvent = "blog_updated"
+	WEBHOOK_EVENT_BLOG_TRASHED            WebhookEvent = "blog_trashed"
+	WEBHOOK_EVENT_BLOG_RESTORED           WebhookEvent = "blog_restored"
+	WEBHOOK_EVENT_BLOG_REMOVED            WebhookEvent = "blog_removed"
+	WEBHOOK_EVENT_BLOG_VIEWED             WebhookEvent = "blog_viewed"
+	WEBHOOK_EVENT_COMMENT_CREATED         WebhookEvent = "comment_created"
+	WEBHOOK_EVENT_COMMENT_UPDATED         WebhookEvent = "comment_updated"
+	WEBHOOK_EVENT_COMMENT_REMOVED         WebhookEvent = "comment_removed"
+	WEBHOOK_EVENT_ATTACHMENT_CREATED      WebhookEvent = "attachment_created"
+	WEBHOOK_EVENT_ATTACHMENT_UPDATED      WebhookEvent = "attachment_updated"
+	WEBHOOK_EVENT_ATTACHMENT_TRASHED      WebhookEvent = "attachment_trashed"
+	WEBHOOK_EVENT_ATTACHMENT_REMOVED      WebhookEvent = "attachment_removed"
+	WEBHOOK_EVENT_LABEL_ADDED             WebhookEvent = "label_added"
+	WEBHOOK_EVENT_LABEL_CREATED           WebhookEvent = "label_created"
+	WEBHOOK_EVENT_LABEL_DELETED           WebhookEvent = "label_deleted"
+	WEBHOOK_EVENT_LABEL_REMOVED           WebhookEvent = "label_removed"
+	WEBHOOK_EVENT_SPACE_CREATED           WebhookEvent = "space_created"
+	WEBHOOK_EVENT_SPACE_UPDATED           WebhookEvent = "space_updated"
+	WEBHOOK_EVENT_SPACE_REMOVED           WebhookEvent = "space_removed"
+	WEBHOOK_EVENT_USER_CREATED            WebhookEvent = "user_created"
+	WEBHOOK_EVENT_USER_REMOVED            WebhookEvent = "user_removed"
+)
+
+// Webhook request headers
+const (
+	WEBHOOK_HEADER_EVENT     = "X-Event-Key"
+	WEBHOOK_HEADER_SIGNATURE = "X-Hub-Signature"
+)
+
+// _WEBHOOK_MAX_BODY_SIZE is default max size of webhook payload
+const _WEBHOOK_MAX_BODY_SIZE = 5 * 1024 * 1024
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WebhookEvent is webhook event type
+type WebhookEvent string
+
+// WebhookMessage contains webhook event data
+type WebhookMessage struct {
+	Type      WebhookEvent    // Event type
+	Timestamp time.Time       // Event date
+	User      *User           // User who triggered the event
+	Content   *Content        // Page, blog post or attachment
+	Comment   *Comment        // Comment (only for comment events)
+	Space     *Space          // Space
+	Label     *Label          // Label (only for label events)
+	Payload   json.RawMessage // Original payload
+}
+
+// WebhookHandler is function called for received webhook event
+type WebhookHandler func(message *WebhookMessage) error
+
+// WebhookReceiver is http.Handler for Confluence webhooks
+type WebhookReceiver struct {
+	// MaxBodySize is max size of payload (5 MB by default)
+	MaxBodySize int64
+
+	// OnError is called for every request processing error
+	OnError func(r *http.Request, err error)
+
+	secret   []byte
+	handlers map[WebhookEvent][]WebhookHandler
+	mu       sync.RWMutex
+}
+
+// webhookPayload is raw webhook payload
+type webhookPayload struct {
+	Event         string          `json:"event"`
+	EventType     string          `json:"eventType"`
+	Timestamp     *Timestamp      `json:"timestamp"`
+	UserName      string          `json:"userName"`
+	UserKey       string          `json:"userKey"`
+	UserAccountID string          `json:"userAccountId"`
+	User          *webhookUser    `json:"user"`
+	Page          *webhookContent `json:"page"`
+	Blog          *webhookContent `json:"blog"`
+	Comment       *webhookContent `json:"comment"`
+	Attachment    *webhookContent `json:"attachment"`
+	Labeled       *webhookContent `json:"labeled"`
+	Space         *webhookSpace   `json:"space"`
+	Label         *webhookLabel   `json:"label"`
+}
+
+// webhookContent is content data from webhook payload
+type webhookContent struct {
+	ID                    webhookID       `json:"id"`
+	Type                  string          `json:"contentType"`
+	Title                 string          `json:"title"`
+	FileName              string          `json:"fileName"`
+	MediaType             string          `json:"fileMimeType"`
+	FileSize              int             `json:"fileSize"`
+	Comment               string          `json:"comment"`
+	SpaceKey              string          `json:"spaceKey"`
+	CreatorName           string          `json:"creatorName"`
+	CreatorAccountID      string          `json:"creatorAccountId"`
+	LastModifierName      string          `json:"lastModifierName"`
+	LastModifierAccountID string          `json:"lastModifierAccountId"`
+	CreationDate          *Timestamp      `json:"creationDate"`
+	ModificationDate      *Timestamp      `json:"modificationDate"`
+	Version               int             `json:"version"`
+	Self                  string          `json:"self"`
+	Parent                *webhookContent `json:"parent"`
+	Container             *webhookContent `json:"container"`
+}
+
+// webhookSpace is space data from webhook payload
+type webhookSpace struct {
+	ID   int    `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Self string `json:"self"`
+}
+
+// webhookLabel is label data from webhook payload
+type webhookLabel struct {
+	ID     webhookID `json:"id"`
+	Name   string    `json:"name"`
+	Prefix string    `json:"prefix"`
+}
+
+// webhookUser is user data from webhook payload
+type webhookUser struct {
+	Name        string `json:"username"`
+	Key         string `json:"userKey"`
+	AccountID   string `json:"accountId"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+}
+
+// webhookID is ID which can be encoded as number or string
+type webhookID string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Webhook errors
+var (
+	ErrWebhookNoSignature  = errors.New("Webhook request has no signature")
+	ErrWebhookBadSignature = errors.New("Webhook request has invalid signature")
+	ErrWebhookNoEventType  = errors.New("Webhook request has no event type")
+	ErrWebhookTooLarge     = errors.New("Webhook payload is too large")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewWebhookReceiver creates new webhook receiver. If secret is not empty, every
+// request must be signed with this secret.
+func NewWebhookReceiver(secret string) *WebhookReceiver {
+	return &WebhookReceiver{
+		MaxBodySize: _WEBHOOK_MAX_BODY_SIZE,
+		secret:      []byte(secret),
+		handlers:    map[WebhookEvent][]WebhookHandler{},
+	}
+}
+
+// ParseWebhookMessage decodes webhook payload. If event type is empty, it's taken
+// from payload.
+func ParseWebhookMessage(eventType WebhookEvent, payload []byte) (*WebhookMessage, error) {
+	data := &webhookPayload{}
+	err := json.Unmarshal(payload, data)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode webhook payload: %w", err)
+	}
+
+	event := &WebhookMessage{
+		Type:    getWebhookEventType(eventType, data),
+		User:    data.getUser(),
+		Space:   data.Space.toSpace(),
+		Label:   data.Label.toLabel(),
+		Payload: payload,
+	}
+
+	if event.Type == "" {
+		return nil, ErrWebhookNoEventType
+	}
+
+	if data.Timestamp != nil {
+		event.Timestamp = data.Timestamp.Time
+	}
+
+	switch {
+	case data.Attachment != nil:
+		event.Content = data.Attachment.toContent(CONTENT_TYPE_ATTACHMENT)
+	case data.Page != nil:
+		event.Content = data.Page.toContent(CONTENT_TYPE_PAGE)
+	case data.Blog != nil:
+		event.Content = data.Blog.toContent(CONTENT_TYPE_BLOGPOST)
+	case data.Labeled != nil:
+		event.Content = data.Labeled.toContent("")
+	}
+
+	if data.Comment != nil {
+		event.Comment = &Comment{Content: *data.Comment.toContent(CONTENT_TYPE_COMMENT)}
+
+		if data.Comment.Parent != nil {
+			event.Comment.Parent = data.Comment.Parent.toContent(CONTENT_TYPE_PAGE)
+		}
+	}
+
+	if event.Space == nil {
+		event.Space = getWebhookSpace(event.Content, event.Comment)
+	}
+
+	return event, nil
+}
+
+// ValidateWebhookSignature validates webhook payload signature. Signature must
+// have format "sha256=<hex encoded HMAC>".
+func ValidateWebhookSignature(secret, payload []byte, signature string) bool {
+	alg, sig, ok := strings.Cut(signature, "=")
+
+	if !ok || !strings.EqualFold(alg, "sha256") {
+		return false
+	}
+
+	expected, err := hex.DecodeString(sig)
+
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Handle registers handler for events with given type. WEBHOOK_EVENT_ANY can be
+// used for handling all events. Handlers are called in registration order.
+func (r *WebhookReceiver) Handle(eventType WebhookEvent, handler WebhookHandler) *WebhookReceiver {
+	r.mu.Lock()
+	r.handlers[eventType] = append(r.handlers[eventType], handler)
+	r.mu.Unlock()
+
+	return r
+}
+
+// ServeHTTP implements http.Handler
+func (r *WebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	status, err := r.process(req)
+
+	if err != nil && r.OnError != nil {
+		r.OnError(req, err)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(status)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// process reads, validates and dispatches webhook request
+func (r *WebhookReceiver) process(req *http.Request) (int, error) {
+	payload, err := io.ReadAll(io.LimitReader(req.Body, r.MaxBodySize+1))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if int64(len(payload)) > r.MaxBodySize {
+		return http.StatusRequestEntityTooLarge, ErrWebhookTooLarge
+	}
+
+	if len(r.secret) != 0 {
+		signature := req.Header.Get(WEBHOOK_HEADER_SIGNATURE)
+
+		switch {
+		case signature == "":
+			return http.StatusUnauthorized, ErrWebhookNoSignature
+		case !ValidateWebhookSignature(r.secret, payload, signature):
+			return http.StatusUnauthorized, ErrWebhookBadSignature
+		}
+	}
+
+	eventType := WebhookEvent(req.Header.Get(WEBHOOK_HEADER_EVENT))
+
+	if eventType == WEBHOOK_EVENT_PING {
+		return http.StatusOK, nil
+	}
+
+	event, err := ParseWebhookMessage(eventType, payload)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	for _, handler := range r.getHandlers(event.Type) {
+		err = handler(event)
+
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("Can't handle %s event: %w", event.Type, err)
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// getHandlers returns handlers for given event type
+func (r *WebhookReceiver) getHandlers(eventType WebhookEvent) []WebhookHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []WebhookHandler
+
+	result = append(result, r.handlers[eventType]...)
+
+	return append(result, r.handlers[WEBHOOK_EVENT_ANY]...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UnmarshalJSON is custom ID unmarshaler
+func (id *webhookID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	*id = webhookID(strings.Trim(string(b), `"`))
+
+	return nil
+}
+
+// getUser returns user who triggered the event
+func (p *webhookPayload) getUser() *User {
+	if p.User != nil {
+		return &User{
+			Name:        p.User.Name,
+			Key:         p.User.Key,
+			AccountID:   p.User.AccountID,
+			DisplayName: p.User.DisplayName,
+			Email:       p.User.Email,
+		}
+	}
+
+	if p.UserName == "" && p.UserKey == "" && p.UserAccountID == "" {
+		return nil
+	}
+
+	return &User{Name: p.UserName, Key: p.UserKey, AccountID: p.UserAccountID}
+}
+
+// toContent converts webhook content data to content
+func (c *webhookContent) toContent(contentType string) *Content {
+	content := &Content{
+		ID:     string(c.ID),
+		Type:   contentType,
+		Status: CONTENT_STATUS_CURRENT,
+		Title:  c.Title,
+	}
+
+	if c.Type != "" {
+		content.Type = c.Type
+	}
+
+	if c.FileName != "" && content.Title == "" {
+		content.Title = c.FileName
+	}
+
+	if c.SpaceKey != "" {
+		content.Space = &Space{Key: c.SpaceKey}
+	}
+
+	if c.Self != "" {
+		content.Links = &Links{Self: c.Self}
+	}
+
+	if c.MediaType != "" || c.FileSize != 0 || c.Comment != "" {
+		content.Metadata = &Metadata{MediaType: c.MediaType}
+		content.Extensions = &Extensions{MediaType: c.MediaType, FileSize: c.FileSize, Comment: c.Comment}
+	}
+
+	if c.Version != 0 || c.ModificationDate != nil {
+		content.Version = &Version{
+			Number: c.Version,
+			By:     getWebhookUser(c.LastModifierName, c.LastModifierAccountID),
+		}
+
+		if c.ModificationDate != nil {
+			content.Version.When = &Date{c.ModificationDate.Time}
+		}
+	}
+
+	if c.CreatorName != "" || c.CreatorAccountID != "" || c.CreationDate != nil {
+		content.History = &History{
+			CreatedBy: getWebhookUser(c.CreatorName, c.CreatorAccountID),
+		}
+
+		if c.CreationDate != nil {
+			content.History.CreatedDate = &Date{c.CreationDate.Time}
+		}
+	}
+
+	if c.Container != nil {
+		content.Container = &Container{ID: ContainerID(c.Container.ID), Title: c.Container.Title}
+	}
+
+	return content
+}
+
+// toSpace converts webhook space data to space
+func (s *webhookSpace) toSpace() *Space {
+	if s == nil {
+		return nil
+	}
+
+	space := &Space{ID: s.ID, Key: s.Key, Name: s.Name, Type: s.Type}
+
+	if s.Self != "" {
+		space.Links = &Links{Self: s.Self}
+	}
+
+	return space
+}
+
+// toLabel converts webhook label data to label
+func (l *webhookLabel) toLabel() *Label {
+	if l == nil {
+		return nil
+	}
+
+	return &Label{ID: string(l.ID), Name: l.Name, Prefix: l.Prefix}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getWebhookEventType returns event type from header or payload
+func getWebhookEventType(eventType WebhookEvent, data *webhookPayload) WebhookEvent {
+	switch {
+	case eventType != "":
+		return eventType
+	case data.EventType != "":
+		return WebhookEvent(data.EventType)
+	}
+
+	return WebhookEvent(data.Event)
+}
+
+// getWebhookUser creates user with given name or account ID
+func getWebhookUser(name, accountID string) *User {
+	if name == "" && accountID == "" {
+		return nil
+	}
+
+	return &User{Name: name, AccountID: accountID}
+}
+
+// getWebhookSpace returns space of event content
+func getWebhookSpace(content *Content, comment *Comment) *Space {
+	switch {
+	case content != nil && content.Space != nil:
+		return content.Space
+	case comment != nil && comment.Space != nil:
+		return comment.Space
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package confluence
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// _WEBHOOK_PAGE_SIZE is number of webhooks fetched with one request
+const _WEBHOOK_PAGE_SIZE = 100
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WebhookReconcileParameters is params for webhooks reconciliation
+type WebhookReconcileParameters struct {
+	// Prune enables removing of webhooks which are not defined
+	Prune bool
+
+	// DryRun enables reporting changes without applying them
+	DryRun bool
+
+	// ForceSecret enables updating of all webhooks which have secret in
+	// definition. Server may not return secret, so changed secret can't be
+	// detected otherwise.
+	ForceSecret bool
+}
+
+// WebhookReconcileReport contains info about reconciliation changes
+type WebhookReconcileReport struct {
+	Created   []*Webhook
+	Updated   []*Webhook
+	Deleted   []*Webhook
+	Unchanged []*Webhook
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrWebhookNoName is returned if webhook definition has no name
+var ErrWebhookNoName = errors.New("Webhook name is mandatory and must be set")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetAllWebhooks fetches all webhooks. All pages of webhooks are fetched
+// automatically.
+func (api *API) GetAllWebhooks() ([]*Webhook, error) {
+	var result []*Webhook
+
+	params := WebhookParameters{Limit: _WEBHOOK_PAGE_SIZE}
+
+	for {
+		webhooks, err := api.GetWebhooks(params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, webhooks.Results...)
+
+		if isLastPage(webhooks.Size, webhooks.Limit, params.Limit) {
+			break
+		}
+
+		params.Start += webhooks.Size
+	}
+
+	return result, nil
+}
+
+// ReconcileWebhooks makes webhooks on server match given definitions. Webhooks
+// are matched by name, existing webhooks with different URL, events, active flag
+// or secret are updated. Secret is compared only if server returns it and
+// definition contains it, so changing only secret of webhook doesn't trigger
+// update if server hides it (use ForceSecret for updating such webhooks), and
+// definition without secret doesn't remove existing secret.
+func (api *API) ReconcileWebhooks(desired []*Webhook, params WebhookReconcileParameters) (*WebhookReconcileReport, error) {
+	names := map[string]bool{}
+
+	for _, webhook := range desired {
+		switch {
+		case webhook.Name == "":
+			return nil, ErrWebhookNoName
+		case names[webhook.Name]:
+			return nil, fmt.Errorf("Webhook %q defined more than once", webhook.Name)
+		}
+
+		names[webhook.Name] = true
+	}
+
+	existing, err := api.GetAllWebhooks()
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch webhooks: %w", err)
+	}
+
+	current := map[string]*Webhook{}
+
+	for _, webhook := range existing {
+		if current[webhook.Name] == nil {
+			current[webhook.Name] = webhook
+		}
+	}
+
+	report := &WebhookReconcileReport{}
+
+	for _, webhook := range desired {
+		known := current[webhook.Name]
+
+		switch {
+		case known == nil:
+			created := webhook
+
+			if !params.DryRun {
+				created, err = api.CreateWebhook(getWebhookInput(webhook, 0))
+
+				if err != nil {
+					return report, fmt.Errorf("Can't create webhook %q: %w", webhook.Name, err)
+				}
+			}
+
+			report.Created = append(report.Created, created)
+
+		case isWebhookChanged(known, webhook, params.ForceSecret):
+			updated := getWebhookInput(webhook, known.ID)
+
+			if !params.DryRun {
+				updated, err = api.UpdateWebhook(updated)
+
+				if err != nil {
+					return report, fmt.Errorf("Can't update webhook %q: %w", webhook.Name, err)
+				}
+			}
+
+			report.Updated = append(report.Updated, updated)
+
+		default:
+			report.Unchanged = append(report.Unchanged, known)
+		}
+	}
+
+	if !params.Prune {
+		return report, nil
+	}
+
+	for _, webhook := range existing {
+		if names[webhook.Name] && current[webhook.Name] == webhook {
+			continue
+		}
+
+		if !params.DryRun {
+			err = api.DeleteWebhook(webhook.ID)
+
+			if err != nil && !errors.Is(err, ErrNoWebhook) {
+				return report, fmt.Errorf("Can't delete webhook %q: %w", webhook.Name, err)
+			}
+		}
+
+		report.Deleted = append(report.Deleted, webhook)
+	}
+
+	return report, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsChanged returns true if reconciliation changed any webhook
+func (r *WebhookReconcileReport) IsChanged() bool {
+	return len(r.Created) != 0 || len(r.Updated) != 0 || len(r.Deleted) != 0
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getWebhookInput returns copy of webhook definition suitable for sending to API
+func getWebhookInput(webhook *Webhook, id int) *Webhook {
+	result := &Webhook{
+		ID:       id,
+		Name:     webhook.Name,
+		URL:      webhook.URL,
+		Events:   slices.Clone(webhook.Events),
+		IsActive: webhook.IsActive,
+	}
+
+	if webhook.Configuration != nil {
+		result.Configuration = &WebhookConfiguration{Secret: webhook.Configuration.Secret}
+	}
+
+	return result
+}
+
+// isWebhookChanged returns true if existing webhook differs from definition. If
+// forceSecret is true, every definition with secret is treated as changed.
+func isWebhookChanged(current, desired *Webhook, forceSecret bool) bool {
+	switch {
+	case current.URL != desired.URL || current.IsActive != desired.IsActive,
+		forceSecret && getWebhookSecret(desired) != "":
+		return true
+	}
+
+	currentEvents, desiredEvents := slices.Clone(current.Events), slices.Clone(desired.Events)
+
+	slices.Sort(currentEvents)
+	slices.Sort(desiredEvents)
+
+	if !slices.Equal(slices.Compact(currentEvents), slices.Compact(desiredEvents)) {
+		return true
+	}
+
+	currentSecret, desiredSecret := getWebhookSecret(current), getWebhookSecret(desired)
+
+	return currentSecret != "" && desiredSecret != "" && currentSecret != desiredSecret
+}
+
+// getWebhookSecret returns webhook secret
+func getWebhookSecret(webhook *Webhook) string {
+	if webhook.Configuration == nil {
+		return ""
+	}
+
+	return webhook.Configuration.Secret
+}